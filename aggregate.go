@@ -0,0 +1,87 @@
+package geohash
+
+import (
+	"sync"
+)
+
+// CellStats are the statistics of the values added to one cell of an Aggregator.
+type CellStats struct {
+	Count int
+	Sum   float64
+	Min   float64
+	Max   float64
+	Mean  float64
+}
+
+// Aggregator computes statistics of a metric for each geohash cell at a fixed bit depth, in a single pass over the
+// values, such as for analytics over a large dataset.
+//
+// As with Bucketer, an Aggregator is safe for concurrent use by multiple goroutines.
+type Aggregator struct {
+	bitDepth int64
+
+	mutex sync.Mutex
+	cells map[int64]*CellStats
+}
+
+// NewAggregator will create an Aggregator that groups values into cells of the requested bitDepth.
+func NewAggregator(bitDepth int64) *Aggregator {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	return &Aggregator{
+		bitDepth: bitDepth,
+		cells:    map[int64]*CellStats{},
+	}
+}
+
+// BitDepth returns the bit depth of the cells.
+func (a *Aggregator) BitDepth() int64 {
+	return a.bitDepth
+}
+
+// Add includes value in the statistics of the cell the supplied coordinate belongs to and returns that cell.
+func (a *Aggregator) Add(lat float64, lng float64, value float64) int64 {
+	geohash := EncodeInt(lat, lng, a.bitDepth)
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	stats, found := a.cells[geohash]
+	if !found {
+		a.cells[geohash] = &CellStats{Count: 1, Sum: value, Min: value, Max: value}
+		return geohash
+	}
+
+	stats.Count++
+	stats.Sum += value
+	if value < stats.Min {
+		stats.Min = value
+	}
+	if value > stats.Max {
+		stats.Max = value
+	}
+	return geohash
+}
+
+// Result returns a copy of the statistics of each cell that has had a value added, with the Mean of each calculated
+// from its Sum and Count.
+func (a *Aggregator) Result() map[int64]CellStats {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	output := make(map[int64]CellStats, len(a.cells))
+	for geohash, stats := range a.cells {
+		result := *stats
+		result.Mean = result.Sum / float64(result.Count)
+		output[geohash] = result
+	}
+	return output
+}
+
+// Reset discards all added values.
+func (a *Aggregator) Reset() {
+	a.mutex.Lock()
+	a.cells = map[int64]*CellStats{}
+	a.mutex.Unlock()
+}
@@ -0,0 +1,56 @@
+package geohash
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestAggregator(t *testing.T) {
+	aggregator := NewAggregator(20)
+	if aggregator.BitDepth() != 20 {
+		t.Errorf("Expected %+v but was %+v", 20, aggregator.BitDepth())
+	}
+
+	first := aggregator.Add(37.8324, 112.5584, 4)
+	aggregator.Add(37.8325, 112.5585, -2)
+	aggregator.Add(37.8326, 112.5586, 10)
+	second := aggregator.Add(-33.8688, 151.2093, 7.5)
+
+	expected := map[int64]CellStats{
+		first:  {Count: 3, Sum: 12, Min: -2, Max: 10, Mean: 4},
+		second: {Count: 1, Sum: 7.5, Min: 7.5, Max: 7.5, Mean: 7.5},
+	}
+	if result := aggregator.Result(); !reflect.DeepEqual(expected, result) {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+	if expected := EncodeInt(37.8324, 112.5584, 20); expected != first {
+		t.Errorf("Expected %+v but was %+v", expected, first)
+	}
+
+	aggregator.Reset()
+	if len(aggregator.Result()) != 0 {
+		t.Errorf("Expected no results after Reset()")
+	}
+}
+
+func TestAggregatorConcurrent(t *testing.T) {
+	aggregator := NewAggregator(20)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(value float64) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				aggregator.Add(37.8324, 112.5584, value)
+			}
+		}(float64(i))
+	}
+	wg.Wait()
+
+	expected := CellStats{Count: 1000, Sum: 4500, Min: 0, Max: 9, Mean: 4.5}
+	if result := aggregator.Result()[EncodeInt(37.8324, 112.5584, 20)]; expected != result {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
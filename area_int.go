@@ -0,0 +1,150 @@
+package geohash
+
+import (
+	"fmt"
+	"math"
+)
+
+// CellAreaSqMeters will return the surface area of a geohash integer's cell in square meters.
+//
+// The cell is treated as a rectangle on a sphere of EarthRadiusMeters, bounded by two parallels and two meridians,
+// so cells of the same bitDepth get smaller toward the poles.
+func CellAreaSqMeters(geohash int64, bitDepth int64) float64 {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	minLat, minLng, maxLat, maxLng := DecodeBboxInt(geohash, bitDepth)
+	return bboxAreaSqMeters(minLat, minLng, maxLat, maxLng)
+}
+
+// GlobeCoverageFraction will return the fraction (0 to 1) of the Earth's surface covered by a set of geohash integers.
+//
+// Duplicate hashes are only counted once. Each cell's area is CellAreaSqMeters, so cells near the poles count for
+// less, and the total is divided by the surface area of a sphere of EarthRadiusMeters, 4πr² (about 510 million square
+// kilometers), so a covering of every cell at bitDepth gives 1.
+func GlobeCoverageFraction(hashes []int64, bitDepth int64) float64 {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	var total float64
+	for geohash := range toSet(hashes) {
+		total += CellAreaSqMeters(geohash, bitDepth)
+	}
+	return total / (4 * math.Pi * EarthRadiusMeters * EarthRadiusMeters)
+}
+
+// ExpectedPointsPerCell will return the expected number of points in a cell at bitDepth when totalPoints are spread
+// with a uniform density over the region, to help pick a bitDepth whose cells hold a manageable number of points.
+//
+// The density is per square meter, so the estimate is for a cell at the center of the region and accounts for cells
+// being smaller at higher latitudes; cells near the region's poleward edge hold fewer points. A region that crosses
+// the antimeridian (see BboxCrossesAntimeridian) spans the longitudes across it. When the region has no area all the
+// points are in a single cell.
+func ExpectedPointsPerCell(totalPoints int, region Bbox, bitDepth int64) float64 {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	maxLng := region.MaxLng
+	if region.CrossesAntimeridian() {
+		maxLng += 360
+	}
+	area := bboxAreaSqMeters(region.MinLat, region.MinLng, region.MaxLat, maxLng)
+	if area <= 0 {
+		return float64(totalPoints)
+	}
+
+	center := EncodeInt((region.MinLat+region.MaxLat)/2, normalizeLng((region.MinLng+maxLng)/2), bitDepth)
+	return float64(totalPoints) * CellAreaSqMeters(center, bitDepth) / area
+}
+
+// bboxAreaSqMeters returns the area of the spherical rectangle between the supplied latitudes and longitudes.
+func bboxAreaSqMeters(minLat float64, minLng float64, maxLat float64, maxLng float64) float64 {
+	deltaSinLat := math.Sin(maxLat*math.Pi/180) - math.Sin(minLat*math.Pi/180)
+	deltaLng := (maxLng - minLng) * math.Pi / 180
+	return EarthRadiusMeters * EarthRadiusMeters * deltaSinLat * deltaLng
+}
+
+// overlapSamples is the number of samples along each side of a cell used by CellCircleOverlap.
+const overlapSamples = 32
+
+// CellCircleOverlap will return the fraction (0 to 1) of a geohash integer's cell area that is within radiusMeters of
+// the supplied point.
+//
+// This is CellCircleOverlapSamples with a 32x32 grid of samples, so the fraction is a multiple of 1/1024 and is
+// typically within a few percent of the exact overlap for cells that straddle the circle's edge.
+func CellCircleOverlap(geohash int64, bitDepth int64, lat float64, lng float64, radiusMeters float64) float64 {
+	return CellCircleOverlapSamples(geohash, bitDepth, lat, lng, radiusMeters, overlapSamples)
+}
+
+// CellCircleOverlapSamples will return the fraction (0 to 1) of a geohash integer's cell area that is within
+// radiusMeters of the supplied point, testing a samples x samples grid of equal area samples across the cell.
+//
+// Cells that are entirely outside of the circle return exactly 0. Otherwise the fraction is a multiple of
+// 1/samples^2 and only the samples whose share of the cell the circle's edge passes through can be wrong. The edge
+// passes through at most about 4*samples of them, so the error is at most about 4/samples and is usually far smaller
+// as the samples either side of the edge largely cancel out. Each sample costs a distance calculation, so doubling
+// samples quadruples the time taken. Panics when samples is less than 1.
+func CellCircleOverlapSamples(geohash int64, bitDepth int64, lat float64, lng float64, radiusMeters float64, samples int) float64 {
+	// input validation
+	validateBitDepth(bitDepth)
+	if samples < 1 {
+		panic(fmt.Sprintf("samples must be at least 1, was %d", samples))
+	}
+
+	minLat, minLng, maxLat, maxLng := DecodeBboxInt(geohash, bitDepth)
+	if distanceToRect(Point{Lat: lat, Lng: lng}, minLat, minLng, maxLat, maxLng) > radiusMeters {
+		return 0
+	}
+
+	// spacing samples evenly in the sine of the latitude gives each the same area
+	minSinLat := math.Sin(minLat * math.Pi / 180)
+	maxSinLat := math.Sin(maxLat * math.Pi / 180)
+
+	inside := 0
+	for row := 0; row < samples; row++ {
+		sinLat := minSinLat + (maxSinLat-minSinLat)*(float64(row)+0.5)/float64(samples)
+		sampleLat := math.Asin(sinLat) * 180 / math.Pi
+		for column := 0; column < samples; column++ {
+			sampleLng := minLng + (maxLng-minLng)*(float64(column)+0.5)/float64(samples)
+			if Distance(lat, lng, sampleLat, sampleLng) <= radiusMeters {
+				inside++
+			}
+		}
+	}
+	return float64(inside) / float64(samples*samples)
+}
+
+// WeightedNeighborsInt will return the cells of the 3x3 neighborhood of center (see NeighborhoodsInt) that overlap the
+// circle of radiusMeters around the supplied point, each mapped to its CellCircleOverlap, such as for interpolating a
+// value across the grid.
+//
+// Only cells with a nonzero overlap are included. Longitude wraps around the antimeridian and a cell is only included
+// once where the neighborhood repeats at a pole. The weights are fractions of each cell's own area, see
+// WeightedNeighborsNormalizedInt for weights that sum to 1.
+func WeightedNeighborsInt(center int64, lat float64, lng float64, radiusMeters float64, bitDepth int64) map[int64]float64 {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	output := map[int64]float64{}
+	for _, geohash := range NeighborhoodsInt([]int64{center}, 1, bitDepth)[0] {
+		if overlap := CellCircleOverlap(geohash, bitDepth, lat, lng, radiusMeters); overlap > 0 {
+			output[geohash] = overlap
+		}
+	}
+	return output
+}
+
+// WeightedNeighborsNormalizedInt is the same as WeightedNeighborsInt with the weights scaled to sum to 1, unless no
+// cell overlaps the circle and the result is empty.
+func WeightedNeighborsNormalizedInt(center int64, lat float64, lng float64, radiusMeters float64, bitDepth int64) map[int64]float64 {
+	output := WeightedNeighborsInt(center, lat, lng, radiusMeters, bitDepth)
+
+	total := 0.0
+	for _, weight := range output {
+		total += weight
+	}
+	for geohash, weight := range output {
+		output[geohash] = weight / total
+	}
+	return output
+}
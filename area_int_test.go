@@ -0,0 +1,200 @@
+package geohash
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCellAreaSqMeters(t *testing.T) {
+	equator := CellAreaSqMeters(EncodeInt(0.1, 30, 30), 30)
+	north := CellAreaSqMeters(EncodeInt(60.1, 30, 30), 30)
+
+	if equator <= north {
+		t.Errorf("Expected equator cell %+v to be larger than 60 degree cell %+v", equator, north)
+	}
+
+	// at 60 degrees a cell is roughly half the width of one at the equator
+	if ratio := north / equator; math.Abs(ratio-0.5) > 0.01 {
+		t.Errorf("Expected a ratio of about 0.5 but was %+v", ratio)
+	}
+}
+
+func TestCellAreaSqMetersWholeGlobe(t *testing.T) {
+	var total float64
+	for geohash := int64(0); geohash < 16; geohash++ {
+		total += CellAreaSqMeters(geohash, 4)
+	}
+
+	expected := 4 * math.Pi * EarthRadiusMeters * EarthRadiusMeters
+	if math.Abs(expected-total)/expected > 1e-9 {
+		t.Errorf("Expected %+v but was %+v", expected, total)
+	}
+}
+
+func TestGlobeCoverageFraction(t *testing.T) {
+	scenarios := []struct {
+		desc     string
+		hashes   []int64
+		expected float64
+	}{
+		{desc: "empty", hashes: nil, expected: 0},
+		{desc: "whole globe", hashes: []int64{0, 1, 2, 3}, expected: 1},
+		{desc: "northern hemisphere", hashes: []int64{1, 3}, expected: 0.5},
+		{desc: "duplicates", hashes: []int64{1, 1, 3, 3, 1}, expected: 0.5},
+		{desc: "western hemisphere", hashes: []int64{0, 1}, expected: 0.5},
+	}
+
+	for _, scenario := range scenarios {
+		result := GlobeCoverageFraction(scenario.hashes, 2)
+		if math.Abs(scenario.expected-result) > 1e-12 {
+			t.Errorf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected, result)
+		}
+	}
+
+	// polar cells count for less than equatorial cells
+	polar := GlobeCoverageFraction([]int64{EncodeInt(89.9, 10, 20)}, 20)
+	equatorial := GlobeCoverageFraction([]int64{EncodeInt(0.1, 10, 20)}, 20)
+	if polar >= equatorial/100 {
+		t.Errorf("Expected %+v to be much less than %+v", polar, equatorial)
+	}
+}
+
+func TestExpectedPointsPerCell(t *testing.T) {
+	scenarios := []struct {
+		desc     string
+		total    int
+		region   Bbox
+		bitDepth int64
+		expected float64
+	}{
+		// the globe is 16 cells at bit depth 4 but those at the equator are larger, each sin(45)/8 of the globe's area
+		{desc: "whole globe", total: 1600, region: Bbox{MinLat: -90, MinLng: -180, MaxLat: 90, MaxLng: 180}, bitDepth: 4, expected: 1600 * math.Sqrt(0.5) / 8},
+		{desc: "cell", total: 100, region: Bbox{MinLat: 0, MinLng: 0, MaxLat: 45, MaxLng: 90}, bitDepth: 4, expected: 100},
+		{desc: "finer cells", total: 100, region: Bbox{MinLat: 0, MinLng: 0, MaxLat: 45, MaxLng: 90}, bitDepth: 8, expected: 100 * CellAreaSqMeters(EncodeInt(22.5, 45, 8), 8) / CellAreaSqMeters(EncodeInt(22.5, 45, 4), 4)},
+		{desc: "antimeridian", total: 100, region: Bbox{MinLat: 0, MinLng: 90, MaxLat: 45, MaxLng: -90}, bitDepth: 4, expected: 50},
+		{desc: "no area", total: 100, region: Bbox{MinLat: 10, MinLng: 10, MaxLat: 10, MaxLng: 10}, bitDepth: 20, expected: 100},
+	}
+
+	for _, scenario := range scenarios {
+		result := ExpectedPointsPerCell(scenario.total, scenario.region, scenario.bitDepth)
+		if math.Abs(scenario.expected-result) > 1e-9*scenario.expected {
+			t.Errorf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected, result)
+		}
+	}
+}
+
+func TestCellCircleOverlap(t *testing.T) {
+	geohash := EncodeInt(30.1, 120.1, 30)
+	lat, lng, _, _ := DecodeInt(geohash, 30)
+	minLat, minLng, maxLat, maxLng := DecodeBboxInt(geohash, 30)
+	width := Distance(minLat, minLng, minLat, maxLng)
+	height := Distance(minLat, lng, maxLat, lng)
+
+	scenarios := []struct {
+		desc      string
+		lat       float64
+		lng       float64
+		radius    float64
+		expected  float64
+		tolerance float64
+	}{
+		{desc: "inside", lat: lat, lng: lng, radius: 10000, expected: 1, tolerance: 0},
+		{desc: "outside", lat: lat + 1, lng: lng, radius: 10000, expected: 0, tolerance: 0},
+		// half of a circle centered on the southern edge, with a radius of half the cell width, is within the cell
+		{desc: "half circle", lat: minLat, lng: lng, radius: width / 2, expected: math.Pi * width / (8 * height), tolerance: 0.01},
+	}
+
+	for _, scenario := range scenarios {
+		result := CellCircleOverlap(geohash, 30, scenario.lat, scenario.lng, scenario.radius)
+		if math.Abs(scenario.expected-result) > scenario.tolerance {
+			t.Errorf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected, result)
+		}
+	}
+}
+
+func TestCellCircleOverlapSamples(t *testing.T) {
+	geohash := EncodeInt(30.1, 120.1, 30)
+	_, lng, _, _ := DecodeInt(geohash, 30)
+	minLat, minLng, maxLat, maxLng := DecodeBboxInt(geohash, 30)
+	width := Distance(minLat, minLng, minLat, maxLng)
+	height := Distance(minLat, lng, maxLat, lng)
+	expected := math.Pi * width / (8 * height)
+
+	if result := CellCircleOverlapSamples(geohash, 30, minLat, lng, width/2, 32); result != CellCircleOverlap(geohash, 30, minLat, lng, width/2) {
+		t.Errorf("Expected %+v but was %+v", CellCircleOverlap(geohash, 30, minLat, lng, width/2), result)
+	}
+
+	// the error is within the documented bound of 4/samples
+	for _, samples := range []int{1, 2, 8, 128} {
+		if result := CellCircleOverlapSamples(geohash, 30, minLat, lng, width/2, samples); math.Abs(expected-result) > 4/float64(samples) {
+			t.Errorf("%d: Expected %+v but was %+v", samples, expected, result)
+		}
+	}
+
+	// more samples are closer to the exact overlap
+	if result := CellCircleOverlapSamples(geohash, 30, minLat, lng, width/2, 256); math.Abs(expected-result) > 0.002 {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
+
+func TestCellCircleOverlapSamplesInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic")
+		}
+	}()
+	CellCircleOverlapSamples(EncodeInt(30.1, 120.1, 30), 30, 30.1, 120.1, 1000, 0)
+}
+
+func TestWeightedNeighborsInt(t *testing.T) {
+	geohash := EncodeInt(30.1, 120.1, 30)
+	lat, lng, _, _ := DecodeInt(geohash, 30)
+	_, minLng, maxLat, maxLng := DecodeBboxInt(geohash, 30)
+	width := Distance(maxLat, minLng, maxLat, maxLng)
+
+	// a small circle in the middle of the cell
+	result := WeightedNeighborsInt(geohash, lat, lng, width/4, 30)
+	if len(result) != 1 || result[geohash] != CellCircleOverlap(geohash, 30, lat, lng, width/4) {
+		t.Errorf("Expected only %+v but was %+v", geohash, result)
+	}
+
+	// a large circle covers all 9 cells
+	result = WeightedNeighborsInt(geohash, lat, lng, width*10, 30)
+	if len(result) != 9 {
+		t.Errorf("Expected %+v but was %+v", 9, len(result))
+	}
+	for neighbor, weight := range result {
+		if weight != 1 {
+			t.Errorf("Expected %+v to be %+v but was %+v", neighbor, 1, weight)
+		}
+	}
+
+	// a circle on the north east corner is split evenly between 4 cells
+	result = WeightedNeighborsNormalizedInt(geohash, maxLat, maxLng, width/4, 30)
+	if len(result) != 4 {
+		t.Errorf("Expected %+v but was %+v", 4, len(result))
+	}
+	total := 0.0
+	for neighbor, weight := range result {
+		total += weight
+		if math.Abs(weight-0.25) > 0.01 {
+			t.Errorf("Expected %+v to be %+v but was %+v", neighbor, 0.25, weight)
+		}
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("Expected %+v but was %+v", 1, total)
+	}
+
+	if result := WeightedNeighborsNormalizedInt(geohash, lat+1, lng, width, 30); len(result) != 0 {
+		t.Errorf("Expected no neighbors but was %+v", result)
+	}
+
+	// a circle on the antimeridian is split evenly with the cell across it
+	eastern := EncodeInt(10, 179.9999, 30)
+	eastLat, _, _, _ := DecodeInt(eastern, 30)
+	result = WeightedNeighborsNormalizedInt(eastern, eastLat, 180, width/4, 30)
+	western := StepInt(eastern, 0, 1, 30)
+	if len(result) != 2 || math.Abs(result[eastern]-0.5) > 0.01 || math.Abs(result[western]-0.5) > 0.01 {
+		t.Errorf("Expected %+v and %+v to be split evenly but was %+v", eastern, western, result)
+	}
+}
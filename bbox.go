@@ -0,0 +1,133 @@
+package geohash
+
+import (
+	"math"
+)
+
+// Bbox is a bounding box in degrees.
+//
+// A box that crosses the antimeridian has a MinLng greater than its MaxLng.
+type Bbox struct {
+	MinLat float64
+	MinLng float64
+	MaxLat float64
+	MaxLng float64
+}
+
+// BboxCrossesAntimeridian will return true when a bounding box from minLng east to maxLng crosses the
+// antimeridian (+/-180), which is the case when minLng is greater than maxLng.
+//
+// Longitudes outside of -180 to 180 are wrapped first.
+func BboxCrossesAntimeridian(minLng float64, maxLng float64) bool {
+	return normalizeLng(minLng) > normalizeLng(maxLng)
+}
+
+// CrossesAntimeridian will return true when the bounding box crosses the antimeridian, see BboxCrossesAntimeridian.
+func (b Bbox) CrossesAntimeridian() bool {
+	return BboxCrossesAntimeridian(b.MinLng, b.MaxLng)
+}
+
+// SegmentIntersectsBbox will return true when any part of the straight lat/lng segment from p1 to p2 is within the
+// bounding box, including its edges.
+//
+// This is the (Liang-Barsky) clipping of a closed box, so a segment entirely inside the box, one that only clips a
+// corner and one that runs along or just touches an edge all intersect, as do vertical, horizontal and zero length
+// segments. CoverPolygonInt clips its edges against each cell in the same way but is stricter, only counting an edge
+// that passes through the cell's interior, so an edge lying along a cell boundary does not cover the cells either
+// side of it. The segment is planar in lat/lng and does not wrap around the antimeridian, but a bounding box that
+// crosses it (see BboxCrossesAntimeridian) is tested as its parts either side of it.
+func SegmentIntersectsBbox(p1 Point, p2 Point, b Bbox) bool {
+	if b.CrossesAntimeridian() {
+		_, _, okEast := clipSegment(p1, p2, b.MinLat, normalizeLng(b.MinLng), b.MaxLat, 180)
+		_, _, okWest := clipSegment(p1, p2, b.MinLat, -180, b.MaxLat, normalizeLng(b.MaxLng))
+		return okEast || okWest
+	}
+
+	_, _, ok := clipSegment(p1, p2, b.MinLat, b.MinLng, b.MaxLat, b.MaxLng)
+	return ok
+}
+
+// metersPerDegree is the length of one degree of latitude (or longitude at the equator) on a sphere of EarthRadiusMeters.
+const metersPerDegree = EarthRadiusMeters * math.Pi / 180
+
+// ExpandBboxMeters will grow a bounding box by marginMeters on every side.
+//
+// The longitude margin is how far east or west a circle of marginMeters reaches, asin(sin(angle)/cos(lat)), around a
+// point at the latitude of the box nearest a pole, so the result contains every point within marginMeters of the box
+// however large the margin. Latitudes are clamped to +/-90, a box that reaches a pole or grows to more than 360
+// degrees wide spans all longitudes, and a box that grows over the antimeridian wraps (leaving MinLng greater than
+// MaxLng).
+func ExpandBboxMeters(b Bbox, marginMeters float64) Bbox {
+	latMargin := marginMeters / metersPerDegree
+	polarLat := math.Max(math.Abs(b.MinLat), math.Abs(b.MaxLat))
+	lngMargin := math.Inf(1)
+	if ratio := math.Sin(marginMeters/EarthRadiusMeters) / math.Cos(polarLat*math.Pi/180); ratio < 1 {
+		lngMargin = math.Asin(ratio) * 180 / math.Pi
+	}
+
+	output := Bbox{
+		MinLat: math.Max(b.MinLat-latMargin, -90),
+		MaxLat: math.Min(b.MaxLat+latMargin, 90),
+	}
+
+	width := b.MaxLng - b.MinLng
+	if b.CrossesAntimeridian() {
+		width += 360
+	}
+	if output.MinLat == -90 || output.MaxLat == 90 || math.IsInf(lngMargin, 0) || width+lngMargin*2 >= 360 {
+		output.MinLng = -180
+		output.MaxLng = 180
+		return output
+	}
+
+	output.MinLng = normalizeLng(b.MinLng - lngMargin)
+	output.MaxLng = normalizeLng(b.MaxLng + lngMargin)
+	return output
+}
+
+// UnionBbox will return the smallest bounding box that contains every cell of a set of geohash integers.
+//
+// When the cells are closer together going across the antimeridian than not, the box crosses it (leaving MinLng
+// greater than MaxLng). Returns false for an empty set.
+func UnionBbox(hashes []int64, bitDepth int64) (Bbox, bool) {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	if len(hashes) == 0 {
+		return Bbox{}, false
+	}
+
+	var minLatIdx, maxLatIdx int64 = math.MaxInt64, math.MinInt64
+	columns := map[int64]bool{}
+	for _, geohash := range hashes {
+		latIdx, lngIdx := deinterleave(geohash, bitDepth)
+		if latIdx < minLatIdx {
+			minLatIdx = latIdx
+		}
+		if latIdx > maxLatIdx {
+			maxLatIdx = latIdx
+		}
+		columns[lngIdx] = true
+	}
+
+	var sorted []int64
+	for column := range columns {
+		sorted = append(sorted, column)
+	}
+	sortHashes(sorted)
+
+	// the box is everything except the largest gap between occupied columns, which may be the one that wraps
+	cells := int64(1) << uint64(bitDepth/2)
+	westIdx, eastIdx := sorted[0], sorted[len(sorted)-1]
+	largestGap := sorted[0] + cells - sorted[len(sorted)-1] - 1
+	for index := 1; index < len(sorted); index++ {
+		if gap := sorted[index] - sorted[index-1] - 1; gap > largestGap {
+			largestGap = gap
+			westIdx, eastIdx = sorted[index], sorted[index-1]
+		}
+	}
+
+	minLat, minLng, _, _ := DecodeBboxInt(interleave(minLatIdx, westIdx, bitDepth), bitDepth)
+	_, _, maxLat, maxLng := DecodeBboxInt(interleave(maxLatIdx, eastIdx, bitDepth), bitDepth)
+	return Bbox{MinLat: minLat, MinLng: minLng, MaxLat: maxLat, MaxLng: maxLng}, true
+}
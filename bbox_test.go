@@ -0,0 +1,186 @@
+package geohash
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExpandBboxMeters(t *testing.T) {
+	result := ExpandBboxMeters(Bbox{MinLat: -0.1, MinLng: 10, MaxLat: 0.1, MaxLng: 11}, 1000)
+
+	// one kilometer is about 0.009 degrees at the equator
+	expected := Bbox{MinLat: -0.108993, MinLng: 9.991007, MaxLat: 0.108993, MaxLng: 11.008993}
+	assertBbox(t, expected, result, 0.000001)
+}
+
+func TestExpandBboxMetersHighLatitude(t *testing.T) {
+	result := ExpandBboxMeters(Bbox{MinLat: 59.9, MinLng: 10, MaxLat: 60.1, MaxLng: 11}, 1000)
+
+	// degrees of longitude are about half as long at the northern edge of 60.1 degrees
+	if lngMargin := 10 - result.MinLng; math.Abs(lngMargin-0.018041) > 0.000001 {
+		t.Errorf("Expected a margin of %+v but was %+v", 0.018041, lngMargin)
+	}
+}
+
+func TestExpandBboxMetersLargeMargin(t *testing.T) {
+	for _, center := range []Point{{Lat: 70, Lng: 10}, {Lat: -75, Lng: 30}, {Lat: 40, Lng: -179}} {
+		result := ExpandBboxMeters(Bbox{MinLat: center.Lat, MinLng: center.Lng, MaxLat: center.Lat, MaxLng: center.Lng}, 1000000)
+
+		// every point of the circle is inside the box, allowing for rounding
+		for bearing := 0.0; bearing < 360; bearing += 0.5 {
+			lat, lng := DestinationPoint(center.Lat, center.Lng, bearing, 999999.999)
+			inside := lat >= result.MinLat && lat <= result.MaxLat
+			if result.CrossesAntimeridian() {
+				inside = inside && (lng >= result.MinLng || lng <= result.MaxLng)
+			} else {
+				inside = inside && lng >= result.MinLng && lng <= result.MaxLng
+			}
+			if !inside {
+				t.Errorf("%+v: Expected %+v,%+v at %+v to be inside %+v", center, lat, lng, bearing, result)
+			}
+		}
+	}
+}
+
+func TestExpandBboxMetersClamp(t *testing.T) {
+	result := ExpandBboxMeters(Bbox{MinLat: 89.99, MinLng: 10, MaxLat: 89.999, MaxLng: 11}, 10000)
+	assertBbox(t, Bbox{MinLat: 89.900068, MinLng: -180, MaxLat: 90, MaxLng: 180}, result, 0.000001)
+
+	result = ExpandBboxMeters(Bbox{MinLat: 0, MinLng: -179, MaxLat: 1, MaxLng: 179}, 10000000)
+	assertBbox(t, Bbox{MinLat: -89.932036, MinLng: -180, MaxLat: 90, MaxLng: 180}, result, 0.000001)
+}
+
+func TestExpandBboxMetersAntimeridian(t *testing.T) {
+	result := ExpandBboxMeters(Bbox{MinLat: 0, MinLng: 179.995, MaxLat: 0.01, MaxLng: 179.999}, 1000)
+	if result.MinLng < 179.98 || result.MaxLng > -179.99 {
+		t.Errorf("Expected the box to wrap across the antimeridian but was %+v", result)
+	}
+
+	// already crossing
+	result = ExpandBboxMeters(Bbox{MinLat: 0, MinLng: 179, MaxLat: 1, MaxLng: -179}, 1000)
+	if result.MinLng > 179 || result.MinLng < 178.9 || result.MaxLng < -179 || result.MaxLng > -178.9 {
+		t.Errorf("Expected the box to still cross the antimeridian but was %+v", result)
+	}
+}
+
+func assertBbox(t *testing.T, expected Bbox, result Bbox, epsilon float64) {
+	t.Helper()
+
+	if !ApproxEqual(expected.MinLat, result.MinLat, epsilon) || !ApproxEqual(expected.MinLng, result.MinLng, epsilon) ||
+		!ApproxEqual(expected.MaxLat, result.MaxLat, epsilon) || !ApproxEqual(expected.MaxLng, result.MaxLng, epsilon) {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
+
+func TestUnionBbox(t *testing.T) {
+	hashes := []int64{EncodeInt(10, 10, 4), EncodeInt(-10, -10, 4)}
+
+	// at bit depth 4 cells are 45 by 90 degrees
+	result, ok := UnionBbox(hashes, 4)
+	if !ok {
+		t.Fatalf("Expected a bbox")
+	}
+	assertBbox(t, Bbox{MinLat: -45, MinLng: -90, MaxLat: 45, MaxLng: 90}, result, 0)
+
+	// every cell is inside the bbox
+	hashes = BboxesInt(30, 120, 30.1, 120.1, 30)
+	result, _ = UnionBbox(hashes, 30)
+	for _, geohash := range hashes {
+		minLat, minLng, maxLat, maxLng := DecodeBboxInt(geohash, 30)
+		if minLat < result.MinLat || minLng < result.MinLng || maxLat > result.MaxLat || maxLng > result.MaxLng {
+			t.Errorf("Expected cell %+v to be inside %+v", geohash, result)
+		}
+	}
+}
+
+func TestUnionBboxAntimeridian(t *testing.T) {
+	hashes := []int64{EncodeInt(10, 179.5, 10), EncodeInt(12, -179.5, 10)}
+
+	// at bit depth 10 cells are 5.625 by 11.25 degrees
+	result, _ := UnionBbox(hashes, 10)
+	assertBbox(t, Bbox{MinLat: 5.625, MinLng: 168.75, MaxLat: 16.875, MaxLng: -168.75}, result, 0)
+}
+
+func TestUnionBboxEmpty(t *testing.T) {
+	if _, ok := UnionBbox(nil, 10); ok {
+		t.Errorf("Expected no bbox for an empty set")
+	}
+}
+
+func TestBboxCrossesAntimeridian(t *testing.T) {
+	tests := []struct {
+		minLng   float64
+		maxLng   float64
+		expected bool
+	}{
+		{-10, 10, false},
+		{10, 10, false},
+		{170, -170, true},
+		{179.9, 180, false},
+		{-180, 180, false},
+		{170, 190, true},
+	}
+	for _, test := range tests {
+		if result := BboxCrossesAntimeridian(test.minLng, test.maxLng); result != test.expected {
+			t.Errorf("Expected BboxCrossesAntimeridian(%v, %v) %+v but was %+v", test.minLng, test.maxLng, test.expected, result)
+		}
+		b := Bbox{MinLat: 0, MinLng: test.minLng, MaxLat: 1, MaxLng: test.maxLng}
+		if result := b.CrossesAntimeridian(); result != test.expected {
+			t.Errorf("Expected %+v.CrossesAntimeridian() %+v but was %+v", b, test.expected, result)
+		}
+	}
+
+	// BboxesInt does not handle crossing boxes
+	if results := BboxesInt(0, 170, 1, -170, 10); len(results) != 0 {
+		t.Errorf("Expected no results but was %+v", results)
+	}
+}
+
+func TestSegmentIntersectsBbox(t *testing.T) {
+	b := Bbox{MinLat: 0, MinLng: 0, MaxLat: 10, MaxLng: 10}
+
+	scenarios := []struct {
+		desc     string
+		p1       Point
+		p2       Point
+		b        Bbox
+		expected bool
+	}{
+		{desc: "inside", p1: Point{Lat: 2, Lng: 2}, p2: Point{Lat: 8, Lng: 3}, b: b, expected: true},
+		{desc: "through", p1: Point{Lat: -5, Lng: 5}, p2: Point{Lat: 15, Lng: 5}, b: b, expected: true},
+		{desc: "one end inside", p1: Point{Lat: 5, Lng: 5}, p2: Point{Lat: 50, Lng: 50}, b: b, expected: true},
+		{desc: "clips a corner", p1: Point{Lat: 9, Lng: -1}, p2: Point{Lat: 11, Lng: 1}, b: b, expected: true},
+		{desc: "touches a corner", p1: Point{Lat: 11, Lng: 9}, p2: Point{Lat: 9, Lng: 11}, b: b, expected: true},
+		{desc: "misses a corner", p1: Point{Lat: 11, Lng: 9.5}, p2: Point{Lat: 9.5, Lng: 11}, b: b, expected: false},
+		{desc: "along an edge", p1: Point{Lat: 0, Lng: -5}, p2: Point{Lat: 0, Lng: 15}, b: b, expected: true},
+		{desc: "horizontal outside", p1: Point{Lat: 11, Lng: -5}, p2: Point{Lat: 11, Lng: 15}, b: b, expected: false},
+		{desc: "vertical outside", p1: Point{Lat: -5, Lng: 11}, p2: Point{Lat: 15, Lng: 11}, b: b, expected: false},
+		{desc: "short of the box", p1: Point{Lat: 5, Lng: -10}, p2: Point{Lat: 5, Lng: -1}, b: b, expected: false},
+		{desc: "point inside", p1: Point{Lat: 5, Lng: 5}, p2: Point{Lat: 5, Lng: 5}, b: b, expected: true},
+		{desc: "point outside", p1: Point{Lat: 5, Lng: 15}, p2: Point{Lat: 5, Lng: 15}, b: b, expected: false},
+		{
+			desc: "antimeridian box east part", p1: Point{Lat: 5, Lng: 175}, p2: Point{Lat: 6, Lng: 176},
+			b: Bbox{MinLat: 0, MinLng: 170, MaxLat: 10, MaxLng: -170}, expected: true,
+		},
+		{
+			desc: "antimeridian box west part", p1: Point{Lat: 5, Lng: -175}, p2: Point{Lat: 6, Lng: -176},
+			b: Bbox{MinLat: 0, MinLng: 170, MaxLat: 10, MaxLng: -170}, expected: true,
+		},
+		{
+			desc: "antimeridian box missed", p1: Point{Lat: 5, Lng: -10}, p2: Point{Lat: 5, Lng: 10},
+			b: Bbox{MinLat: 0, MinLng: 170, MaxLat: 10, MaxLng: -170}, expected: false,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		if result := SegmentIntersectsBbox(scenario.p1, scenario.p2, scenario.b); scenario.expected != result {
+			t.Errorf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected, result)
+		}
+	}
+
+	// edge contact intersects the box but does not cover a cell for CoverPolygonInt
+	edge := [2]Point{{Lat: 0, Lng: -5}, {Lat: 0, Lng: 15}}
+	if !SegmentIntersectsBbox(edge[0], edge[1], b) || segmentCrossesRect(edge[0], edge[1], b.MinLat, b.MinLng, b.MaxLat, b.MaxLng) {
+		t.Errorf("Expected an edge along the box to intersect it without crossing it")
+	}
+}
@@ -0,0 +1,138 @@
+package geohash
+
+import (
+	"math"
+	"sync"
+)
+
+// Bucketer aggregates coordinates into geohash cells (buckets) at a fixed bit depth.
+//
+// A Bucketer is safe for concurrent use by multiple goroutines.
+type Bucketer struct {
+	bitDepth int64
+
+	mutex  sync.Mutex
+	counts map[int64]int
+}
+
+// NewBucketer will create a Bucketer that groups coordinates into cells of the requested bitDepth.
+func NewBucketer(bitDepth int64) *Bucketer {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	return &Bucketer{
+		bitDepth: bitDepth,
+		counts:   map[int64]int{},
+	}
+}
+
+// BitDepth returns the bit depth of the buckets.
+func (b *Bucketer) BitDepth() int64 {
+	return b.bitDepth
+}
+
+// Bucket returns the bucket (geohash integer) the supplied coordinate belongs to.
+func (b *Bucketer) Bucket(lat float64, lng float64) int64 {
+	return EncodeInt(lat, lng, b.bitDepth)
+}
+
+// Centroid returns the center of the supplied bucket, which is typically where it should be displayed.
+func (b *Bucketer) Centroid(hash int64) (lat float64, lng float64) {
+	lat, lng, _, _ = DecodeInt(hash, b.bitDepth)
+	return
+}
+
+// Tally adds one to the count of the bucket the supplied coordinate belongs to and returns that bucket.
+func (b *Bucketer) Tally(lat float64, lng float64) int64 {
+	bucket := b.Bucket(lat, lng)
+
+	b.mutex.Lock()
+	b.counts[bucket]++
+	b.mutex.Unlock()
+
+	return bucket
+}
+
+// Counts returns a copy of the number of tallied coordinates in each bucket.
+func (b *Bucketer) Counts() map[int64]int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	output := make(map[int64]int, len(b.counts))
+	for bucket, count := range b.counts {
+		output[bucket] = count
+	}
+	return output
+}
+
+// Reset discards all tallied counts.
+func (b *Bucketer) Reset() {
+	b.mutex.Lock()
+	b.counts = map[int64]int{}
+	b.mutex.Unlock()
+}
+
+// Collisions will return the indexes of the points that share a cell at bitDepth with at least one other point, keyed
+// by the geohash integer of each shared cell, such as for finding near duplicate records to merge.
+//
+// Only cells containing two or more of the points are reported, with their indexes in ascending order. The points
+// are encoded in a single pass building the map, after which the cells with a single point are removed.
+func Collisions(points []Point, bitDepth int64) map[int64][]int {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	output := map[int64][]int{}
+	for index, point := range points {
+		geohash := EncodeInt(point.Lat, point.Lng, bitDepth)
+		output[geohash] = append(output[geohash], index)
+	}
+
+	for geohash, indexes := range output {
+		if len(indexes) < 2 {
+			delete(output, geohash)
+		}
+	}
+	return output
+}
+
+// SuggestBitDepth will return the even bitDepth, up to MaxBitDepth, at which the average number of points in each
+// non-empty cell is closest to targetPerCell, such as for choosing the resolution of a heatmap.
+//
+// The average only falls as the cells get smaller, so rather than trying every bit depth it bisects them, encoding the
+// points at around 6 of the candidate depths. When two depths are equally close the coarser one is returned, so a
+// targetPerCell of 1 or less gives the first depth at which no two points share a cell (see Collisions) or
+// MaxBitDepth if there is none. Returns 0 when there are no points.
+func SuggestBitDepth(points []Point, targetPerCell float64) int64 {
+	if len(points) == 0 {
+		return 0
+	}
+	targetPerCell = math.Max(targetPerCell, 1)
+
+	occupancy := func(bitDepth int64) float64 {
+		cells := map[int64]bool{}
+		for _, point := range points {
+			cells[EncodeInt(point.Lat, point.Lng, bitDepth)] = true
+		}
+		return float64(len(points)) / float64(len(cells))
+	}
+
+	// find the coarsest depth at or below the target, if there is one
+	low, high := int64(1), int64(MaxBitDepth/2+1)
+	for low < high {
+		mid := (low + high) / 2
+		if occupancy(mid*2) <= targetPerCell {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+	if low > MaxBitDepth/2 {
+		return MaxBitDepth
+	}
+
+	bitDepth := low * 2
+	if bitDepth > 2 && targetPerCell-occupancy(bitDepth) >= occupancy(bitDepth-2)-targetPerCell {
+		bitDepth -= 2
+	}
+	return bitDepth
+}
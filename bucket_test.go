@@ -0,0 +1,129 @@
+package geohash
+
+import (
+	"math"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestBucketerBucketAndCentroid(t *testing.T) {
+	bucketer := NewBucketer(32)
+
+	bucket := bucketer.Bucket(37.8324, 112.5584)
+	expected := EncodeInt(37.8324, 112.5584, 32)
+	if expected != bucket {
+		t.Errorf("Expected %+v but was %+v", expected, bucket)
+	}
+
+	lat, lng := bucketer.Centroid(bucket)
+	if !CellContainsPoint(bucket, 32, lat, lng) {
+		t.Errorf("Expected centroid %+v,%+v to be inside bucket %+v", lat, lng, bucket)
+	}
+}
+
+func TestBucketerTally(t *testing.T) {
+	bucketer := NewBucketer(20)
+
+	first := bucketer.Tally(37.8324, 112.5584)
+	bucketer.Tally(37.8325, 112.5585)
+	second := bucketer.Tally(-33.8688, 151.2093)
+
+	counts := bucketer.Counts()
+	if counts[first] != 2 {
+		t.Errorf("Expected %+v but was %+v", 2, counts[first])
+	}
+	if counts[second] != 1 {
+		t.Errorf("Expected %+v but was %+v", 1, counts[second])
+	}
+
+	bucketer.Reset()
+	if len(bucketer.Counts()) != 0 {
+		t.Errorf("Expected no counts after Reset()")
+	}
+}
+
+func TestBucketerTallyConcurrent(t *testing.T) {
+	bucketer := NewBucketer(20)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				bucketer.Tally(37.8324, 112.5584)
+			}
+		}()
+	}
+	wg.Wait()
+
+	bucket := bucketer.Bucket(37.8324, 112.5584)
+	if count := bucketer.Counts()[bucket]; count != 1000 {
+		t.Errorf("Expected %+v but was %+v", 1000, count)
+	}
+}
+
+func TestCollisions(t *testing.T) {
+	points := []Point{
+		{Lat: 37.8324, Lng: 112.5584},
+		{Lat: -33.8688, Lng: 151.2093},
+		{Lat: 37.83241, Lng: 112.55841},
+		{Lat: 51.5007, Lng: -0.1246},
+		{Lat: -33.86881, Lng: 151.20931},
+		{Lat: 37.83242, Lng: 112.55842},
+	}
+
+	expected := map[int64][]int{
+		EncodeInt(37.8324, 112.5584, 30):  {0, 2, 5},
+		EncodeInt(-33.8688, 151.2093, 30): {1, 4},
+	}
+	result := Collisions(points, 30)
+	if !reflect.DeepEqual(expected, result) {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+
+	// at full resolution the points are all in different cells
+	if result := Collisions(points, MaxBitDepth); len(result) != 0 {
+		t.Errorf("Expected no collisions but was %+v", result)
+	}
+}
+
+func TestSuggestBitDepth(t *testing.T) {
+	// 4 clusters of 100 points, with the points of each cluster spread over about 100 meters
+	var points []Point
+	for _, center := range []Point{{Lat: 37.8324, Lng: 112.5584}, {Lat: -33.8688, Lng: 151.2093}, {Lat: 51.5007, Lng: -0.1246}, {Lat: 40.7128, Lng: -74.006}} {
+		for i := 0; i < 100; i++ {
+			points = append(points, Point{Lat: center.Lat + float64(i%10)*0.0001, Lng: center.Lng + float64(i/10)*0.0001})
+		}
+	}
+
+	// compare against trying every depth
+	for _, target := range []float64{1, 2, 5, 20, 100, 400} {
+		var expected int64
+		closest := math.Inf(1)
+		for bitDepth := int64(2); bitDepth <= MaxBitDepth; bitDepth += 2 {
+			cells := map[int64]bool{}
+			for _, point := range points {
+				cells[EncodeInt(point.Lat, point.Lng, bitDepth)] = true
+			}
+			if difference := math.Abs(float64(len(points))/float64(len(cells)) - target); difference < closest {
+				expected, closest = bitDepth, difference
+			}
+		}
+
+		if result := SuggestBitDepth(points, target); expected != result {
+			t.Errorf("%+v: Expected %+v but was %+v", target, expected, result)
+		}
+	}
+
+	if result := SuggestBitDepth(points, 0.5); SuggestBitDepth(points, 1) != result {
+		t.Errorf("Expected %+v but was %+v", SuggestBitDepth(points, 1), result)
+	}
+	if result := SuggestBitDepth(append(points, points[0]), 1); result != MaxBitDepth {
+		t.Errorf("Expected %+v but was %+v", MaxBitDepth, result)
+	}
+	if result := SuggestBitDepth(nil, 10); result != 0 {
+		t.Errorf("Expected %+v but was %+v", 0, result)
+	}
+}
@@ -0,0 +1,66 @@
+package geohash
+
+import (
+	"math"
+)
+
+// minCentroidLength is the shortest summed vector (relative to the number of cells) with a meaningful direction.
+const minCentroidLength = 1e-12
+
+// CentroidInt will return the average position of the centers of the supplied geohash integers' cells.
+//
+// The mean is computed on the sphere: each cell center is converted to a unit vector, the vectors are summed and the
+// direction of the sum is converted back to a latitude and longitude. Unlike averaging raw latitudes and longitudes
+// this gives sensible results for cells either side of the antimeridian or around a pole.
+//
+// ok will be false when hashes is empty or when the centers cancel out (e.g. two antipodal cells) such that there is
+// no meaningful average.
+func CentroidInt(hashes []int64, bitDepth int64) (lat float64, lng float64, ok bool) {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	var sum vector
+	for _, geohash := range hashes {
+		cellLat, cellLng, _, _ := DecodeInt(geohash, bitDepth)
+		v := toVector(cellLat, cellLng)
+		sum.x += v.x
+		sum.y += v.y
+		sum.z += v.z
+	}
+
+	if len(hashes) == 0 || dot(sum, sum) <= minCentroidLength*float64(len(hashes)) {
+		return 0, 0, false
+	}
+
+	lat, lng = sum.toLatLng()
+	return lat, lng, true
+}
+
+// BoundingCircle will return a circle that contains all of the supplied geohash integers' cells, for a quick distance
+// pre-filter before more exact work.
+//
+// This is an approximation rather than the smallest such circle: the center is the CentroidInt of the cells (or the
+// center of the first cell when they have no meaningful average) and the radius is the great-circle distance from it
+// to the farthest corner of any cell. A single cell gets a circle around its center, while the radius can be up to
+// twice the minimal one when the cells are unevenly spread. An empty hashes returns a zero Point and radius.
+func BoundingCircle(hashes []int64, bitDepth int64) (center Point, radiusMeters float64) {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	if len(hashes) == 0 {
+		return Point{}, 0
+	}
+
+	lat, lng, ok := CentroidInt(hashes, bitDepth)
+	if !ok {
+		lat, lng, _, _ = DecodeInt(hashes[0], bitDepth)
+	}
+	center = Point{Lat: lat, Lng: lng}
+
+	for _, geohash := range hashes {
+		for _, corner := range CellCorners(geohash, bitDepth) {
+			radiusMeters = math.Max(radiusMeters, Distance(lat, lng, corner.Lat, corner.Lng))
+		}
+	}
+	return center, radiusMeters
+}
@@ -0,0 +1,93 @@
+package geohash
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCentroidInt(t *testing.T) {
+	hashes := []int64{
+		EncodeInt(10, 20, 40),
+		EncodeInt(-10, 20, 40),
+	}
+
+	lat, lng, ok := CentroidInt(hashes, 40)
+	if !ok {
+		t.Fatalf("Expected a centroid")
+	}
+	if math.Abs(lat) > 0.0001 || math.Abs(lng-20) > 0.0001 {
+		t.Errorf("Expected %+v,%+v but was %+v,%+v", 0, 20, lat, lng)
+	}
+}
+
+func TestCentroidIntAntimeridian(t *testing.T) {
+	hashes := []int64{
+		EncodeInt(5, 179, 40),
+		EncodeInt(5, -179, 40),
+	}
+
+	lat, lng, ok := CentroidInt(hashes, 40)
+	if !ok {
+		t.Fatalf("Expected a centroid")
+	}
+	if math.Abs(math.Abs(lng)-180) > 0.0001 {
+		t.Errorf("Expected a longitude near 180 but was %+v", lng)
+	}
+	if math.Abs(lat-5) > 0.01 {
+		t.Errorf("Expected a latitude near 5 but was %+v", lat)
+	}
+}
+
+func TestCentroidIntEmpty(t *testing.T) {
+	if _, _, ok := CentroidInt(nil, 40); ok {
+		t.Errorf("Expected no centroid for an empty slice")
+	}
+
+	// antipodal cells have no meaningful average
+	hashes := []int64{
+		EncodeInt(0.1, 0.1, 4),
+		EncodeInt(-0.1, -179.9, 4),
+	}
+	if _, _, ok := CentroidInt(hashes, 4); ok {
+		t.Errorf("Expected no centroid for antipodal cells")
+	}
+}
+
+func TestBoundingCircle(t *testing.T) {
+	scenarios := []struct {
+		desc     string
+		hashes   []int64
+		bitDepth int64
+	}{
+		{desc: "single", hashes: []int64{EncodeInt(37.8324, 112.5584, 30)}, bitDepth: 30},
+		{desc: "block", hashes: BboxesInt(37.8, 112.5, 37.9, 112.6, 30), bitDepth: 30},
+		{desc: "spread", hashes: []int64{EncodeInt(10, 20, 30), EncodeInt(-10, 25, 30), EncodeInt(0, 40, 30)}, bitDepth: 30},
+		{desc: "antimeridian", hashes: []int64{EncodeInt(5, 179.9, 30), EncodeInt(5, -179.9, 30)}, bitDepth: 30},
+		{desc: "antipodal", hashes: []int64{EncodeInt(0.1, 0.1, 4), EncodeInt(-0.1, -179.9, 4)}, bitDepth: 4},
+	}
+
+	for _, scenario := range scenarios {
+		center, radius := BoundingCircle(scenario.hashes, scenario.bitDepth)
+		for _, geohash := range scenario.hashes {
+			for _, corner := range CellCorners(geohash, scenario.bitDepth) {
+				if distance := Distance(center.Lat, center.Lng, corner.Lat, corner.Lng); distance > radius+1e-6 {
+					t.Errorf("%s: Expected %+v to be within %+v of %+v but was %+v", scenario.desc, corner, radius, center, distance)
+				}
+			}
+		}
+	}
+
+	// a single cell is centered on the cell
+	geohash := EncodeInt(37.8324, 112.5584, 30)
+	center, radius := BoundingCircle([]int64{geohash}, 30)
+	if expected := CellCenter(geohash, 30); math.Abs(expected.Lat-center.Lat) > 1e-9 || math.Abs(expected.Lng-center.Lng) > 1e-9 {
+		t.Errorf("Expected %+v but was %+v", expected, center)
+	}
+	if corners := CellCorners(geohash, 30); radius < Distance(center.Lat, center.Lng, corners[0].Lat, corners[0].Lng)-1e-6 {
+		t.Errorf("Expected a radius of at least the distance to the corners but was %+v", radius)
+	}
+
+	if center, radius := BoundingCircle(nil, 30); center != (Point{}) || radius != 0 {
+		t.Errorf("Expected %+v,%+v but was %+v,%+v", Point{}, 0, center, radius)
+	}
+}
@@ -0,0 +1,55 @@
+package geohash
+
+// Codec holds a bitDepth so that it does not need to be passed to every call.
+//
+// A Codec is immutable and so is safe for concurrent use by multiple goroutines.
+type Codec struct {
+	bitDepth int64
+}
+
+// NewCodec will create a Codec for the supplied bitDepth, returning an error if the bitDepth is invalid.
+func NewCodec(bitDepth int64) (*Codec, error) {
+	// input validation
+	if err := checkBitDepth(bitDepth, MaxBitDepth64); err != nil {
+		return nil, err
+	}
+
+	return &Codec{
+		bitDepth: bitDepth,
+	}, nil
+}
+
+// BitDepth returns the bit depth used by the Codec.
+func (c *Codec) BitDepth() int64 {
+	return c.bitDepth
+}
+
+// Encode is the same as EncodeInt at the Codec's bit depth.
+func (c *Codec) Encode(latitude float64, longitude float64) int64 {
+	return EncodeInt(latitude, longitude, c.bitDepth)
+}
+
+// Decode is the same as DecodeInt at the Codec's bit depth.
+func (c *Codec) Decode(geohash int64) (lat float64, lng float64, latErr float64, lngErr float64) {
+	return DecodeInt(geohash, c.bitDepth)
+}
+
+// DecodeBbox is the same as DecodeBboxInt at the Codec's bit depth.
+func (c *Codec) DecodeBbox(geohash int64) (minLat float64, minLng float64, maxLat float64, maxLng float64) {
+	return DecodeBboxInt(geohash, c.bitDepth)
+}
+
+// Neighbor is the same as NeighborInt at the Codec's bit depth.
+func (c *Codec) Neighbor(geohash int64, bearing Bearing) int64 {
+	return NeighborInt(geohash, bearing, c.bitDepth)
+}
+
+// Neighbors is the same as NeighborsInt at the Codec's bit depth.
+func (c *Codec) Neighbors(geohash int64) []int64 {
+	return NeighborsInt(geohash, c.bitDepth)
+}
+
+// Bboxes is the same as BboxesInt at the Codec's bit depth.
+func (c *Codec) Bboxes(minLat float64, minLon float64, maxLat float64, maxLon float64) []int64 {
+	return BboxesInt(minLat, minLon, maxLat, maxLon, c.bitDepth)
+}
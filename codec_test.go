@@ -0,0 +1,46 @@
+package geohash
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewCodec(t *testing.T) {
+	if _, err := NewCodec(MaxBitDepth64 + 2); err == nil {
+		t.Errorf("Expected an error for a bit depth above MaxBitDepth64")
+	}
+	if _, err := NewCodec(31); err == nil {
+		t.Errorf("Expected an error for an odd bit depth")
+	}
+	if _, err := NewCodec(0); err == nil {
+		t.Errorf("Expected an error for a zero bit depth")
+	}
+}
+
+func TestCodec(t *testing.T) {
+	codec, err := NewCodec(32)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	geohash := codec.Encode(37.8324, 112.5584)
+	if expected := EncodeInt(37.8324, 112.5584, 32); expected != geohash {
+		t.Errorf("Expected %+v but was %+v", expected, geohash)
+	}
+
+	lat, lng, _, _ := codec.Decode(geohash)
+	expectedLat, expectedLng, _, _ := DecodeInt(geohash, 32)
+	if expectedLat != lat || expectedLng != lng {
+		t.Errorf("Expected %+v,%+v but was %+v,%+v", expectedLat, expectedLng, lat, lng)
+	}
+
+	if expected, result := NeighborInt(geohash, North, 32), codec.Neighbor(geohash, North); expected != result {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+	if expected, result := NeighborsInt(geohash, 32), codec.Neighbors(geohash); !reflect.DeepEqual(expected, result) {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+	if expected, result := BboxesInt(30, 120, 30.1, 120.1, 32), codec.Bboxes(30, 120, 30.1, 120.1); !reflect.DeepEqual(expected, result) {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
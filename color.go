@@ -0,0 +1,41 @@
+package geohash
+
+// CellColor will return a stable color for a geohash integer's cell, for telling cells apart when rendering coverings.
+//
+// The hue is picked pseudo-randomly for the cell's parent (at bitDepth-2) and then offset by the cell's quadrant, so
+// the 4 cells that share a parent have related but distinct hues while other adjacent cells usually differ more. The
+// saturation and value stay within a range that keeps colors readable on a map. Only integer arithmetic is used, so
+// the color of a cell is the same across runs and platforms.
+func CellColor(geohash int64, bitDepth int64) (r uint8, g uint8, b uint8) {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	mixed := mix64(uint64(geohash>>2) ^ uint64(bitDepth)<<(64-8))
+	hue := (int(mixed%360) + QuadrantInt(geohash, bitDepth)*20) % 360
+	saturation := 150 + int((mixed>>16)%80)
+	value := 190 + int((mixed>>32)%60)
+	return hsvToRGB(hue, saturation, value)
+}
+
+// hsvToRGB converts a hue in degrees (0 to 359) and a saturation and value (0 to 255) into red, green and blue.
+func hsvToRGB(hue int, saturation int, value int) (r uint8, g uint8, b uint8) {
+	remainder := (hue % 60) * 255 / 60
+	p := value * (255 - saturation) / 255
+	q := value * (255 - saturation*remainder/255) / 255
+	t := value * (255 - saturation*(255-remainder)/255) / 255
+
+	switch hue / 60 {
+	case 0:
+		return uint8(value), uint8(t), uint8(p)
+	case 1:
+		return uint8(q), uint8(value), uint8(p)
+	case 2:
+		return uint8(p), uint8(value), uint8(t)
+	case 3:
+		return uint8(p), uint8(q), uint8(value)
+	case 4:
+		return uint8(t), uint8(p), uint8(value)
+	default:
+		return uint8(value), uint8(p), uint8(q)
+	}
+}
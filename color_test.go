@@ -0,0 +1,46 @@
+package geohash
+
+import (
+	"testing"
+)
+
+func TestCellColor(t *testing.T) {
+	// stable across runs and platforms
+	r, g, b := CellColor(4064984913515641, MaxBitDepth)
+	if expected, result := [3]uint8{24, 209, 70}, [3]uint8{r, g, b}; expected != result {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+
+	// the cells that share a parent are all different colors
+	seen := map[[3]uint8]bool{}
+	for _, child := range ChildrenInt(EncodeInt(37.8324, 112.5584, 20), 20) {
+		r, g, b := CellColor(child, 22)
+		seen[[3]uint8{r, g, b}] = true
+	}
+	if len(seen) != 4 {
+		t.Errorf("Expected %+v colors but was %+v", 4, seen)
+	}
+}
+
+func TestHsvToRGB(t *testing.T) {
+	scenarios := []struct {
+		hue        int
+		saturation int
+		value      int
+		expected   [3]uint8
+	}{
+		{hue: 0, saturation: 255, value: 255, expected: [3]uint8{255, 0, 0}},
+		{hue: 120, saturation: 255, value: 255, expected: [3]uint8{0, 255, 0}},
+		{hue: 240, saturation: 255, value: 255, expected: [3]uint8{0, 0, 255}},
+		{hue: 60, saturation: 255, value: 255, expected: [3]uint8{255, 255, 0}},
+		{hue: 300, saturation: 255, value: 255, expected: [3]uint8{255, 0, 255}},
+		{hue: 200, saturation: 0, value: 128, expected: [3]uint8{128, 128, 128}},
+	}
+
+	for _, scenario := range scenarios {
+		r, g, b := hsvToRGB(scenario.hue, scenario.saturation, scenario.value)
+		if result := [3]uint8{r, g, b}; scenario.expected != result {
+			t.Errorf("%d: Expected %+v but was %+v", scenario.hue, scenario.expected, result)
+		}
+	}
+}
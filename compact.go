@@ -0,0 +1,161 @@
+package geohash
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Compact will shrink a covering by replacing every group of four cells that share a parent with that parent cell,
+// repeating until no complete groups remain (or bitDepth 2 is reached).
+//
+// The results carry their own, possibly coarser, bitDepth and are sorted by position along the geohash curve.
+// Duplicate inputs are ignored. See Uncompact for the inverse.
+func Compact(hashes []int64, bitDepth int64) []Hash {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	var output []Hash
+	current := toSet(hashes)
+	for depth := bitDepth; len(current) > 0; depth -= 2 {
+		if depth == 2 {
+			for geohash := range current {
+				output = append(output, Hash{Value: geohash, BitDepth: depth})
+			}
+			break
+		}
+
+		parents := map[int64]int{}
+		for geohash := range current {
+			parents[geohash>>2]++
+		}
+
+		next := map[int64]bool{}
+		for geohash := range current {
+			if parents[geohash>>2] == 4 {
+				next[geohash>>2] = true
+			} else {
+				output = append(output, Hash{Value: geohash, BitDepth: depth})
+			}
+		}
+		current = next
+	}
+
+	sortByCurve(output)
+	return output
+}
+
+// SimplifyCovering will shrink a covering to at most maxCells cells by replacing groups of cells that share a parent
+// with that parent cell, even when only threshold (1 to 4) of the parent's four children are present.
+//
+// As with Compact every complete group of four is always replaced, which does not change the area covered. While
+// there are still more than maxCells cells, groups with at least threshold children are also replaced, the most
+// complete groups and the finest cells first. Each such group grows the covering by the area of its missing
+// children, so the result always contains the original covering but may cover considerably more of the globe; a
+// lower threshold shrinks the covering faster in exchange for more over coverage. The result may still have more
+// than maxCells cells when no groups with enough children remain.
+//
+// The results carry their own, possibly coarser, bitDepth and are sorted by position along the geohash curve.
+// Duplicate inputs are ignored.
+func SimplifyCovering(hashes []int64, bitDepth int64, maxCells int, threshold int) []Hash {
+	// input validation
+	validateBitDepth(bitDepth)
+	if threshold < 1 || threshold > 4 {
+		panic(fmt.Sprintf("threshold must be between 1 and 4, was %d", threshold))
+	}
+
+	var output []Hash
+	current := toSet(hashes)
+	total := len(current)
+	// the number of cells already output that are within each parent of the current cells
+	buried := map[int64]int{}
+	for depth := bitDepth; len(current) > 0; depth -= 2 {
+		if depth == 2 {
+			for geohash := range current {
+				output = append(output, Hash{Value: geohash, BitDepth: depth})
+			}
+			break
+		}
+
+		parents := map[int64]int{}
+		for geohash := range current {
+			parents[geohash>>2]++
+		}
+
+		// replace the most complete groups first
+		candidates := make([]int64, 0, len(parents))
+		for parent, count := range parents {
+			if count >= threshold || count == 4 {
+				candidates = append(candidates, parent)
+			}
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			if parents[candidates[i]] != parents[candidates[j]] {
+				return parents[candidates[i]] > parents[candidates[j]]
+			}
+			return candidates[i] < candidates[j]
+		})
+
+		next := map[int64]bool{}
+		for _, parent := range candidates {
+			if parents[parent] < 4 && total <= maxCells {
+				break
+			}
+			next[parent] = true
+			total -= parents[parent] - 1 + buried[parent]
+		}
+
+		nextBuried := map[int64]int{}
+		for parent, count := range buried {
+			if !next[parent] {
+				nextBuried[parent>>2] += count
+			}
+		}
+		for geohash := range current {
+			if !next[geohash>>2] {
+				output = append(output, Hash{Value: geohash, BitDepth: depth})
+				nextBuried[geohash>>4]++
+			}
+		}
+		current, buried = next, nextBuried
+	}
+
+	// a coarser cell may now contain finer cells that were not replaced (already removed from total), which follow
+	// it along the curve
+	sortByCurve(output)
+	simplified := output[:0]
+	for _, hash := range output {
+		if len(simplified) > 0 {
+			last := simplified[len(simplified)-1]
+			if hash.BitDepth > last.BitDepth && hash.Value>>uint64(hash.BitDepth-last.BitDepth) == last.Value {
+				continue
+			}
+		}
+		simplified = append(simplified, hash)
+	}
+	return simplified
+}
+
+// Uncompact will convert a set of mixed bitDepth hashes into a covering at a single targetDepth.
+//
+// Coarser hashes are expanded into all of their descendants and finer hashes are truncated to the ancestor that
+// contains them. The result is sorted ascending without duplicates.
+func Uncompact(hashes []Hash, targetDepth int64) []int64 {
+	// input validation
+	validateBitDepth(targetDepth)
+
+	var output []int64
+	for _, hash := range hashes {
+		validateBitDepth(hash.BitDepth)
+
+		if hash.BitDepth >= targetDepth {
+			output = append(output, hash.Value>>uint64(hash.BitDepth-targetDepth))
+			continue
+		}
+
+		shift := uint64(targetDepth - hash.BitDepth)
+		for descendant := hash.Value << shift; descendant < (hash.Value+1)<<shift; descendant++ {
+			output = append(output, descendant)
+		}
+	}
+	return NormalizeHashes(output)
+}
@@ -0,0 +1,167 @@
+package geohash
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompact(t *testing.T) {
+	parent := EncodeInt(37.8324, 112.5584, 22)
+
+	// all four children of parent, all sixteen grandchildren of another cell and one lone cell
+	var hashes []int64
+	for child := int64(0); child < 4; child++ {
+		hashes = append(hashes, parent<<2|child)
+	}
+	neighbor := EncodeInt(-33.8688, 151.2093, 20)
+	for grandchild := int64(0); grandchild < 16; grandchild++ {
+		hashes = append(hashes, neighbor<<4|grandchild)
+	}
+	lone := EncodeInt(10, 10, 24)
+	hashes = append(hashes, lone, lone)
+
+	expected := []Hash{{Value: parent, BitDepth: 22}, {Value: neighbor, BitDepth: 20}, {Value: lone, BitDepth: 24}}
+	sortHashes(hashes)
+	result := Compact(hashes, 24)
+
+	added, removed := DiffCoverings(hashesOf(expected), hashesOf(result))
+	if len(result) != len(expected) || len(added) != 0 || len(removed) != 0 {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
+
+func TestCompactWholeGlobe(t *testing.T) {
+	var hashes []int64
+	for geohash := int64(0); geohash < 256; geohash++ {
+		hashes = append(hashes, geohash)
+	}
+
+	result := Compact(hashes, 8)
+	if len(result) != 4 {
+		t.Errorf("Expected the 4 cells of bit depth 2 but was %+v", result)
+	}
+}
+
+func TestSimplifyCovering(t *testing.T) {
+	parent := EncodeInt(37.8324, 112.5584, 22)
+	lone := EncodeInt(10, 10, 24)
+	hashes := []int64{parent << 2, parent<<2 | 1, parent<<2 | 3, lone}
+
+	scenarios := []struct {
+		desc      string
+		maxCells  int
+		threshold int
+		expected  []Hash
+	}{
+		{desc: "fits", maxCells: 4, threshold: 3, expected: Compact(hashes, 24)},
+		{desc: "enough children", maxCells: 2, threshold: 3, expected: []Hash{{Value: lone, BitDepth: 24}, {Value: parent, BitDepth: 22}}},
+		{desc: "not enough children", maxCells: 2, threshold: 4, expected: Compact(hashes, 24)},
+	}
+
+	for _, scenario := range scenarios {
+		result := SimplifyCovering(hashes, 24, scenario.maxCells, scenario.threshold)
+		if !reflect.DeepEqual(scenario.expected, result) {
+			t.Errorf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected, result)
+		}
+	}
+}
+
+func TestSimplifyCoveringContainsOriginal(t *testing.T) {
+	hashes := CoverRadiusInt(37.8324, 112.5584, 5000, 30)
+	for _, threshold := range []int{1, 2, 3} {
+		result := SimplifyCovering(hashes, 30, 20, threshold)
+		if len(result) >= len(Compact(hashes, 30)) {
+			t.Errorf("%d: Expected fewer cells than Compact but was %+v", threshold, len(result))
+		}
+
+		// any single child is enough to replace a group, so a threshold of 1 always fits
+		if threshold == 1 && len(result) > 20 {
+			t.Errorf("%d: Expected at most %+v cells but was %+v", threshold, 20, len(result))
+		}
+
+		for _, geohash := range hashes {
+			found := false
+			for _, hash := range result {
+				found = found || geohash>>uint64(30-hash.BitDepth) == hash.Value
+			}
+			if !found {
+				t.Errorf("%d: Expected %+v to be covered by %+v", threshold, geohash, result)
+				break
+			}
+		}
+	}
+}
+
+func TestSimplifyCoveringRemovesContainedCells(t *testing.T) {
+	// three complete children of parent and a single grandchild of its fourth child
+	parent := EncodeInt(37.8324, 112.5584, 22)
+	var hashes []int64
+	for child := int64(0); child < 3; child++ {
+		for grandchild := int64(0); grandchild < 4; grandchild++ {
+			hashes = append(hashes, (parent<<2|child)<<2|grandchild)
+		}
+	}
+	hashes = append(hashes, (parent<<2|3)<<2)
+
+	expected := []Hash{{Value: parent, BitDepth: 22}}
+	if result := SimplifyCovering(hashes, 26, 2, 3); !reflect.DeepEqual(expected, result) {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
+
+func TestSimplifyCoveringStopsAtMaxCells(t *testing.T) {
+	// parent has three complete children and two grandchildren of its fourth child, its siblings are complete
+	parent := EncodeInt(37.8324, 112.5584, 22) &^ 3
+	var hashes []int64
+	for child := int64(0); child < 3; child++ {
+		for grandchild := int64(0); grandchild < 4; grandchild++ {
+			hashes = append(hashes, (parent<<2|child)<<2|grandchild)
+		}
+	}
+	hashes = append(hashes, (parent<<2|3)<<2, (parent<<2|3)<<2|1)
+	for sibling := int64(1); sibling < 3; sibling++ {
+		for descendant := int64(0); descendant < 16; descendant++ {
+			hashes = append(hashes, (parent|sibling)<<4|descendant)
+		}
+	}
+
+	// the grandchildren are within parent, so 3 cells already fit without coarsening to the grandparent
+	expected := []Hash{{Value: parent, BitDepth: 22}, {Value: parent | 1, BitDepth: 22}, {Value: parent | 2, BitDepth: 22}}
+	if result := SimplifyCovering(hashes, 26, 4, 3); !reflect.DeepEqual(expected, result) {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
+
+func TestSimplifyCoveringInvalidThreshold(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic")
+		}
+	}()
+	SimplifyCovering([]int64{1}, 30, 1, 5)
+}
+
+func TestUncompact(t *testing.T) {
+	hashes := BboxesInt(30, 120, 30.05, 120.05, 30)
+	sortHashes(hashes)
+
+	result := Uncompact(Compact(hashes, 30), 30)
+	if !reflect.DeepEqual(hashes, result) {
+		t.Errorf("Expected %+v but was %+v", hashes, result)
+	}
+
+	// finer hashes are truncated to the target depth
+	result = Uncompact([]Hash{{Value: 0x1f, BitDepth: 6}, {Value: 0x1e, BitDepth: 6}}, 4)
+	if expected := []int64{0x07}; !reflect.DeepEqual(expected, result) {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
+
+// hashesOf packs the value and bit depth of each hash into one integer so that mixed bit depths can be compared.
+func hashesOf(hashes []Hash) []int64 {
+	var output []int64
+	for _, hash := range hashes {
+		output = append(output, hash.Value<<8|hash.BitDepth)
+	}
+	return output
+}
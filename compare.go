@@ -0,0 +1,24 @@
+package geohash
+
+import (
+	"math"
+)
+
+// ApproxEqual will return true when a and b differ by no more than epsilon.
+func ApproxEqual(a float64, b float64, epsilon float64) bool {
+	return math.Abs(a-b) <= epsilon
+}
+
+// DecodeIntApprox will return true when the decoded center of a geohash integer is within epsilon degrees of both the
+// supplied latitude and longitude.
+func DecodeIntApprox(geohash int64, bitDepth int64, lat float64, lng float64, epsilon float64) bool {
+	resultLat, resultLng, _, _ := DecodeInt(geohash, bitDepth)
+	return ApproxEqual(lat, resultLat, epsilon) && ApproxEqual(lng, resultLng, epsilon)
+}
+
+// CellContainsPoint will return true when the supplied coordinate would encode into the geohash integer at bitDepth.
+//
+// This is usually a better test assertion than comparing decoded centers as it is free of float noise.
+func CellContainsPoint(geohash int64, bitDepth int64, lat float64, lng float64) bool {
+	return EncodeInt(lat, lng, bitDepth) == geohash
+}
@@ -0,0 +1,38 @@
+package geohash
+
+import (
+	"testing"
+)
+
+func TestApproxEqual(t *testing.T) {
+	if !ApproxEqual(37.8324, 37.83245, 0.0001) {
+		t.Errorf("Expected values to be approximately equal")
+	}
+	if ApproxEqual(37.8324, 37.8326, 0.0001) {
+		t.Errorf("Expected values not to be approximately equal")
+	}
+}
+
+func TestDecodeIntApprox(t *testing.T) {
+	if !DecodeIntApprox(4064984913515641, MaxBitDepth, 37.8324, 112.5584, 0.0001) {
+		t.Errorf("Expected decoded value to be approximately 37.8324,112.5584")
+	}
+	if DecodeIntApprox(4064984913515641, MaxBitDepth, 37.8324, 112.5684, 0.0001) {
+		t.Errorf("Expected decoded value not to be approximately 37.8324,112.5684")
+	}
+}
+
+func TestCellContainsPoint(t *testing.T) {
+	geohash := EncodeInt(37.8324, 112.5584, 32)
+	minLat, minLng, maxLat, maxLng := DecodeBboxInt(geohash, 32)
+
+	if !CellContainsPoint(geohash, 32, 37.8324, 112.5584) {
+		t.Errorf("Expected cell %+v to contain the encoded point", geohash)
+	}
+	if !CellContainsPoint(geohash, 32, (minLat+maxLat)/2, (minLng+maxLng)/2) {
+		t.Errorf("Expected cell %+v to contain its center", geohash)
+	}
+	if CellContainsPoint(geohash, 32, maxLat+(maxLat-minLat)/2, maxLng) {
+		t.Errorf("Expected cell %+v not to contain a point north of it", geohash)
+	}
+}
@@ -0,0 +1,106 @@
+package geohash
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// coordPattern matches a pair of coordinates, each either decimal degrees or degrees, minutes and seconds, optionally
+// followed by a hemisphere, separated by a comma, semicolon and/or whitespace.
+var coordPattern = regexp.MustCompile(`^\s*` + coordComponent + `(\s*[,;]\s*|\s*)` + coordComponent + `\s*$`)
+
+// coordComponent matches a single coordinate, capturing the degrees, minutes, seconds and hemisphere.
+const coordComponent = `([-+]?\d+(?:\.\d*)?)(?:\s*°(?:\s*(\d+(?:\.\d*)?)\s*['′’])?(?:\s*(\d+(?:\.\d*)?)\s*(?:"|″|”|''))?)?(?:\s*([NSEWnsew]))?`
+
+// ParseCoordString will parse a latitude and longitude pair from a string in one of the common formats:
+//
+//	"37.8324,112.5584"               decimal degrees separated by a comma (or semicolon) and/or whitespace
+//	"37.8324 112.5584"
+//	"37.8324N 112.5584E"             with hemispheres, where S and W are negative
+//	"37°49'56.6\"N 112°33'30.2\"E"   degrees, minutes and seconds, with optional minutes and seconds
+//
+// The latitude comes first unless the hemispheres show otherwise. An error describing the problem is returned for
+// input that cannot be parsed or for coordinates that are out of range.
+func ParseCoordString(s string) (lat float64, lng float64, err error) {
+	match := coordPattern.FindStringSubmatch(s)
+	if match == nil {
+		return 0, 0, fmt.Errorf("cannot parse coordinates from %q", s)
+	}
+	if match[5] == "" && match[4] == "" {
+		return 0, 0, fmt.Errorf("coordinates in %q must be separated by a comma or whitespace", s)
+	}
+
+	first, firstHemisphere, err := parseCoordComponent(match[1:5])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid coordinates %q: %s", s, err)
+	}
+	second, secondHemisphere, err := parseCoordComponent(match[6:10])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid coordinates %q: %s", s, err)
+	}
+
+	// the hemispheres may show the longitude is first
+	lat, lng = first, second
+	if strings.ContainsAny(firstHemisphere, "EW") || strings.ContainsAny(secondHemisphere, "NS") {
+		if strings.ContainsAny(firstHemisphere, "NS") || strings.ContainsAny(secondHemisphere, "EW") {
+			return 0, 0, fmt.Errorf("invalid coordinates %q: conflicting hemispheres", s)
+		}
+		lat, lng = second, first
+	}
+
+	if lat < -90 || lat > 90 {
+		return 0, 0, fmt.Errorf("latitude must be between -90 and 90, was %v", lat)
+	}
+	if lng < -180 || lng > 180 {
+		return 0, 0, fmt.Errorf("longitude must be between -180 and 180, was %v", lng)
+	}
+	return lat, lng, nil
+}
+
+// EncodeCoordString will parse a latitude and longitude pair from a string (see ParseCoordString) and encode it into
+// a geohash integer of bitDepth, returning an error if either step fails.
+func EncodeCoordString(s string, bitDepth int64) (int64, error) {
+	lat, lng, err := ParseCoordString(s)
+	if err != nil {
+		return 0, err
+	}
+	return EncodeIntE(lat, lng, bitDepth)
+}
+
+// parseCoordComponent converts the degrees, minutes, seconds and hemisphere captured by coordComponent into a signed
+// coordinate and the (upper case) hemisphere.
+func parseCoordComponent(parts []string) (float64, string, error) {
+	degrees, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return 0, "", err
+	}
+
+	for index, divisor := range []float64{60, 3600} {
+		if parts[index+1] == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(parts[index+1], 64)
+		if err != nil {
+			return 0, "", err
+		}
+		if value >= 60 {
+			return 0, "", fmt.Errorf("minutes and seconds must be less than 60, was %v", value)
+		}
+		if degrees < 0 || strings.HasPrefix(parts[0], "-") {
+			degrees -= value / divisor
+		} else {
+			degrees += value / divisor
+		}
+	}
+
+	hemisphere := strings.ToUpper(parts[3])
+	if hemisphere == "S" || hemisphere == "W" {
+		if strings.HasPrefix(parts[0], "-") {
+			return 0, "", fmt.Errorf("a negative coordinate must not have a %s hemisphere", hemisphere)
+		}
+		degrees = -degrees
+	}
+	return degrees, hemisphere, nil
+}
@@ -0,0 +1,69 @@
+package geohash
+
+import (
+	"testing"
+)
+
+func TestParseCoordString(t *testing.T) {
+	scenarios := []struct {
+		input       string
+		expectedLat float64
+		expectedLng float64
+	}{
+		{input: "37.8324,112.5584", expectedLat: 37.8324, expectedLng: 112.5584},
+		{input: " 37.8324, 112.5584 ", expectedLat: 37.8324, expectedLng: 112.5584},
+		{input: "37.8324 112.5584", expectedLat: 37.8324, expectedLng: 112.5584},
+		{input: "-33.8688;151.2093", expectedLat: -33.8688, expectedLng: 151.2093},
+		{input: "37.8324N 112.5584W", expectedLat: 37.8324, expectedLng: -112.5584},
+		{input: "112.5584E, 37.8324S", expectedLat: -37.8324, expectedLng: 112.5584},
+		{input: `37°49'56.64"N 112°33'30.24"E`, expectedLat: 37.8324, expectedLng: 112.5584},
+		{input: `37° 49′ 56.64″ S, 112° 33′ 30.24″ W`, expectedLat: -37.8324, expectedLng: -112.5584},
+		{input: `-37°49'56.64" 112°30'`, expectedLat: -37.8324, expectedLng: 112.5},
+		{input: `37°N 112°E`, expectedLat: 37, expectedLng: 112},
+		{input: "37.8324s 112.5584", expectedLat: -37.8324, expectedLng: 112.5584},
+		{input: "112.5584 37.8324N", expectedLat: 37.8324, expectedLng: 112.5584},
+	}
+
+	for _, scenario := range scenarios {
+		lat, lng, err := ParseCoordString(scenario.input)
+		if err != nil || !ApproxEqual(scenario.expectedLat, lat, 1e-9) || !ApproxEqual(scenario.expectedLng, lng, 1e-9) {
+			t.Errorf("%s: Expected %+v,%+v but was %+v,%+v (%v)", scenario.input, scenario.expectedLat, scenario.expectedLng, lat, lng, err)
+		}
+	}
+}
+
+func TestParseCoordStringInvalid(t *testing.T) {
+	scenarios := []string{
+		"",
+		"37.8324",
+		"37.8324,112.5584,1",
+		"abc,def",
+		"37.8324112.5584",
+		"91,0",
+		"0,181",
+		`37°61'N 112°E`,
+		"37N 112N",
+		"37E 112E",
+		"-37S 112E",
+	}
+
+	for _, scenario := range scenarios {
+		if lat, lng, err := ParseCoordString(scenario); err == nil {
+			t.Errorf("%s: Expected an error but was %+v,%+v", scenario, lat, lng)
+		}
+	}
+}
+
+func TestEncodeCoordString(t *testing.T) {
+	result, err := EncodeCoordString("37.8324, 112.5584", MaxBitDepth)
+	if err != nil || result != 4064984913515641 {
+		t.Errorf("Expected %+v but was %+v (%v)", 4064984913515641, result, err)
+	}
+
+	if _, err := EncodeCoordString("37.8324", MaxBitDepth); err == nil {
+		t.Errorf("Expected an error for unparseable input")
+	}
+	if _, err := EncodeCoordString("37.8324, 112.5584", 3); err == nil {
+		t.Errorf("Expected an error for an invalid bit depth")
+	}
+}
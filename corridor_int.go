@@ -0,0 +1,65 @@
+package geohash
+
+// CorridorInt will return the geohash integers of all the cells within widthMeters of the great-circle segment
+// between two points, a capsule (stadium) shaped region such as the area around a road.
+//
+// A zero length segment gives the cells within widthMeters of the point. The output is sorted ascending without
+// duplicates.
+func CorridorInt(lat1 float64, lng1 float64, lat2 float64, lng2 float64, widthMeters float64, bitDepth int64) []int64 {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	a := Point{Lat: lat1, Lng: lng1}
+	b := Point{Lat: lat2, Lng: lng2}
+
+	// flood out from the cells along the line while the cells are within the corridor
+	queue := LineHashesInt(lat1, lng1, lat2, lng2, bitDepth)
+	onLine := toSet(queue)
+	seen := toSet(queue)
+	var output []int64
+	for len(queue) > 0 {
+		geohash := queue[0]
+		queue = queue[1:]
+
+		if !onLine[geohash] && cellSegmentDistance(geohash, bitDepth, a, b) > widthMeters {
+			continue
+		}
+		output = append(output, geohash)
+
+		for dLat := int64(-1); dLat <= 1; dLat++ {
+			for dLng := int64(-1); dLng <= 1; dLng++ {
+				neighbor, ok := stepCell(geohash, dLat, dLng, bitDepth)
+				if ok && !seen[neighbor] {
+					seen[neighbor] = true
+					queue = append(queue, neighbor)
+				}
+			}
+		}
+	}
+
+	sortHashes(output)
+	return output
+}
+
+// cellSegmentDistance returns the distance in meters between a cell and the great-circle segment from a to b, for a
+// cell that the segment does not pass through.
+//
+// As they do not intersect the nearest points are either a corner of the cell or an end of the segment.
+func cellSegmentDistance(geohash int64, bitDepth int64, a Point, b Point) float64 {
+	minLat, minLng, maxLat, maxLng := DecodeBboxInt(geohash, bitDepth)
+
+	distance := distanceToRect(a, minLat, minLng, maxLat, maxLng)
+	if end := distanceToRect(b, minLat, minLng, maxLat, maxLng); end < distance {
+		distance = end
+	}
+	if distance == 0 {
+		return 0
+	}
+
+	for _, corner := range CellCorners(geohash, bitDepth) {
+		if cornerDistance := distanceToSegment(corner, a, b); cornerDistance < distance {
+			distance = cornerDistance
+		}
+	}
+	return distance
+}
@@ -0,0 +1,61 @@
+package geohash
+
+import (
+	"testing"
+)
+
+func TestCorridorInt(t *testing.T) {
+	results := CorridorInt(30, 120, 30.01, 120.02, 200, 36)
+	found := toSet(results)
+
+	// the line itself is always included
+	for _, geohash := range LineHashesInt(30, 120, 30.01, 120.02, 36) {
+		if !found[geohash] {
+			t.Errorf("Expected line cell %+v to be found", geohash)
+		}
+	}
+
+	// every cell is within the corridor and the nearby cells outside of it are not
+	for _, geohash := range BboxesInt(29.99, 119.99, 30.02, 120.03, 36) {
+		distance := cellSegmentDistance(geohash, 36, Point{30, 120}, Point{30.01, 120.02})
+		if contained := found[geohash]; distance <= 200 && !contained {
+			t.Errorf("Expected cell %+v at %+vm to be found", geohash, distance)
+		}
+	}
+
+	if len(results) <= len(LineHashesInt(30, 120, 30.01, 120.02, 36)) {
+		t.Errorf("Expected the corridor to be wider than the line")
+	}
+	assertSortedUnique(t, results)
+}
+
+func TestCorridorIntPoint(t *testing.T) {
+	results := CorridorInt(30, 120, 30, 120, 500, 36)
+
+	for _, geohash := range results {
+		minLat, minLng, maxLat, maxLng := DecodeBboxInt(geohash, 36)
+		if distance := distanceToRect(Point{30, 120}, minLat, minLng, maxLat, maxLng); distance > 500 {
+			t.Errorf("Expected cell %+v to be within 500m but was %+vm", geohash, distance)
+		}
+	}
+
+	// and every cell within 500m is found
+	found := toSet(results)
+	for _, geohash := range BboxesInt(29.99, 119.99, 30.01, 120.01, 36) {
+		minLat, minLng, maxLat, maxLng := DecodeBboxInt(geohash, 36)
+		if distance := distanceToRect(Point{30, 120}, minLat, minLng, maxLat, maxLng); distance <= 500 && !found[geohash] {
+			t.Errorf("Expected cell %+v at %+vm to be found", geohash, distance)
+		}
+	}
+	assertSortedUnique(t, results)
+}
+
+func assertSortedUnique(t *testing.T, results []int64) {
+	t.Helper()
+
+	for index := 1; index < len(results); index++ {
+		if results[index-1] >= results[index] {
+			t.Errorf("Expected sorted unique values but %+v is followed by %+v", results[index-1], results[index])
+		}
+	}
+}
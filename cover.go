@@ -0,0 +1,362 @@
+package geohash
+
+import (
+	"fmt"
+	"math"
+)
+
+// CoverPolygonInt will return the geohash integers of all the cells at bitDepth that intersect the polygon.
+//
+// The polygon is a single ring of points, which may be open or closed (first point repeated as the last). It is
+// treated as planar in lat/lng, matching the geohash grid, and should not cross the antimeridian. The output is
+// sorted ascending without duplicates.
+func CoverPolygonInt(polygon []Point, bitDepth int64) []int64 {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	var output []int64
+	coverRings([][]Point{polygon}, bitDepth, func(geohash int64) bool {
+		output = append(output, geohash)
+		return true
+	})
+
+	sortHashes(output)
+	return output
+}
+
+// CoverRadiusInt will return the geohash integers of all the cells at bitDepth that are at least partly within
+// radiusMeters (great-circle distance) of the supplied point.
+//
+// The output is sorted ascending without duplicates.
+func CoverRadiusInt(lat float64, lng float64, radiusMeters float64, bitDepth int64) []int64 {
+	return CorridorInt(lat, lng, lat, lng, radiusMeters, bitDepth)
+}
+
+// CoverCapInt will return the geohash integers of all the cells at bitDepth that are at least partly within the
+// spherical cap of points no more than halfAngleDegrees (the angle at the center of the earth) from axis, such as the
+// footprint of a satellite.
+//
+// Unlike CoverRadiusInt the distance to each cell is measured exactly on the sphere, so it is suited to footprints
+// of any size: a cap of more than 90 degrees is larger than a hemisphere and one of 180 degrees or more covers every
+// cell. A cap centered on a pole covers whole rows of cells. The output is sorted ascending without duplicates.
+func CoverCapInt(axis Point, halfAngleDegrees float64, bitDepth int64) []int64 {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	halfAngle := halfAngleDegrees * math.Pi / 180
+
+	// flood out from the cell of the axis while the cells are within the cap, which is connected so is reached whole
+	start := EncodeInt(axis.Lat, axis.Lng, bitDepth)
+	queue := []int64{start}
+	seen := map[int64]bool{start: true}
+	var output []int64
+	for len(queue) > 0 {
+		geohash := queue[0]
+		queue = queue[1:]
+
+		minLat, minLng, maxLat, maxLng := DecodeBboxInt(geohash, bitDepth)
+		if angleToRect(axis, minLat, minLng, maxLat, maxLng) > halfAngle {
+			continue
+		}
+		output = append(output, geohash)
+
+		for dLat := int64(-1); dLat <= 1; dLat++ {
+			for dLng := int64(-1); dLng <= 1; dLng++ {
+				neighbor, ok := stepCell(geohash, dLat, dLng, bitDepth)
+				if ok && !seen[neighbor] {
+					seen[neighbor] = true
+					queue = append(queue, neighbor)
+				}
+			}
+		}
+	}
+
+	sortHashes(output)
+	return output
+}
+
+// CoverCirclesInt will return the union of CoverRadiusInt for each of the centers with the radius at the same index of
+// radiiMeters, such as the delivery areas of a set of stores.
+//
+// The output is sorted ascending without duplicates, even where the circles overlap. Panics when centers and
+// radiiMeters are not the same length. See CoverCirclesRadiusInt when all circles have the same radius.
+func CoverCirclesInt(centers []Point, radiiMeters []float64, bitDepth int64) []int64 {
+	// input validation
+	validateBitDepth(bitDepth)
+	if len(centers) != len(radiiMeters) {
+		panic(fmt.Sprintf("centers and radiiMeters must be the same length, were %d and %d", len(centers), len(radiiMeters)))
+	}
+
+	seen := map[int64]bool{}
+	var output []int64
+	for index, center := range centers {
+		for _, geohash := range CoverRadiusInt(center.Lat, center.Lng, radiiMeters[index], bitDepth) {
+			if !seen[geohash] {
+				seen[geohash] = true
+				output = append(output, geohash)
+			}
+		}
+	}
+
+	sortHashes(output)
+	return output
+}
+
+// CoverCirclesRadiusInt is the same as CoverCirclesInt with every circle having a radius of radiusMeters.
+func CoverCirclesRadiusInt(centers []Point, radiusMeters float64, bitDepth int64) []int64 {
+	radii := make([]float64, len(centers))
+	for index := range radii {
+		radii[index] = radiusMeters
+	}
+	return CoverCirclesInt(centers, radii, bitDepth)
+}
+
+// CoverRotatedRectInt will return the geohash integers of all the cells at bitDepth that intersect a rectangle of
+// widthMeters by heightMeters centered on center, such as the footprint of a camera or radar.
+//
+// With a rotationDegrees of 0 the height runs north to south and the width east to west, and the rectangle is rotated
+// clockwise by rotationDegrees from there (any angle, including negative ones or those beyond 360, is accepted). The
+// corners are found with DestinationPoint and then covered as CoverPolygonInt does, so the edges are straight in
+// lat/lng, which is close to the true rectangle when it is small, and it should not cross the antimeridian. The
+// output is sorted ascending without duplicates.
+func CoverRotatedRectInt(center Point, widthMeters float64, heightMeters float64, rotationDegrees float64, bitDepth int64) []int64 {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	// each corner is the same distance from the center on a bearing offset from the rotation
+	distance := math.Hypot(widthMeters/2, heightMeters/2)
+	offset := math.Atan2(widthMeters/2, heightMeters/2) * 180 / math.Pi
+
+	polygon := make([]Point, 0, 4)
+	for _, bearing := range []float64{offset, 180 - offset, 180 + offset, 360 - offset} {
+		lat, lng := DestinationPoint(center.Lat, center.Lng, math.Mod(rotationDegrees+bearing, 360), distance)
+		polygon = append(polygon, Point{Lat: lat, Lng: lng})
+	}
+	return CoverPolygonInt(polygon, bitDepth)
+}
+
+// CoverRadiusAdaptiveInt will return a covering of the circle of radiusMeters around the supplied point made of at
+// most maxCells cells of mixed bitDepth (up to MaxBitDepth), using large cells where the circle allows.
+//
+// Starting from the smallest cell that encloses the circle, the largest cells that are not entirely inside the circle
+// are repeatedly split into those of their four children that intersect it, until no split fits within maxCells.
+// The union of the returned cells always fully contains the circle, but may extend well beyond it when maxCells is
+// small. A circle that no single cell encloses starts from the (up to 4) cells of bitDepth 2 that intersect it, so a
+// maxCells of less than 4 may be exceeded. The results are sorted by position along the geohash curve.
+func CoverRadiusAdaptiveInt(lat float64, lng float64, radiusMeters float64, maxCells int) []Hash {
+	center := Point{Lat: lat, Lng: lng}
+	intersects := func(hash Hash) bool {
+		minLat, minLng, maxLat, maxLng := DecodeBboxInt(hash.Value, hash.BitDepth)
+		return angleToRect(center, minLat, minLng, maxLat, maxLng)*EarthRadiusMeters <= radiusMeters
+	}
+	inside := func(hash Hash) bool {
+		for _, corner := range CellCorners(hash.Value, hash.BitDepth) {
+			if Distance(lat, lng, corner.Lat, corner.Lng) > radiusMeters {
+				return false
+			}
+		}
+		return true
+	}
+
+	var cells []Hash
+	b := ExpandBboxMeters(Bbox{MinLat: lat, MinLng: lng, MaxLat: lat, MaxLng: lng}, radiusMeters)
+	if geohash, bitDepth := EnclosingHashInt(b.MinLat, b.MinLng, b.MaxLat, b.MaxLng); bitDepth > 0 {
+		cells = append(cells, Hash{Value: geohash, BitDepth: bitDepth})
+	} else {
+		for geohash := int64(0); geohash < 4; geohash++ {
+			if hash := (Hash{Value: geohash, BitDepth: 2}); intersects(hash) {
+				cells = append(cells, hash)
+			}
+		}
+	}
+
+	// finished cells are entirely inside the circle, at MaxBitDepth or have too many children to split
+	finished := make([]bool, len(cells))
+	for {
+		// split the largest unfinished cell first
+		next := -1
+		for index, hash := range cells {
+			if !finished[index] && (next < 0 || hash.BitDepth < cells[next].BitDepth) {
+				next = index
+			}
+		}
+		if next < 0 {
+			break
+		}
+
+		hash := cells[next]
+		if hash.BitDepth >= MaxBitDepth || inside(hash) {
+			finished[next] = true
+			continue
+		}
+
+		var children []Hash
+		for child := int64(0); child < 4; child++ {
+			if childHash := (Hash{Value: hash.Value<<2 | child, BitDepth: hash.BitDepth + 2}); intersects(childHash) {
+				children = append(children, childHash)
+			}
+		}
+		if len(children) == 0 || len(cells)-1+len(children) > maxCells {
+			finished[next] = true
+			continue
+		}
+
+		cells[next] = children[0]
+		cells = append(cells, children[1:]...)
+		finished = append(finished, make([]bool, len(children)-1)...)
+	}
+
+	sortByCurve(cells)
+	return cells
+}
+
+// coverRings will call fn with each cell at bitDepth that intersects the polygon formed by the first (outer) ring
+// minus any subsequent rings (holes).
+//
+// The polygon is treated as planar in lat/lng, matching the geohash grid, and should not cross the antimeridian.
+// Returns false if fn returned false to stop the covering early.
+func coverRings(rings [][]Point, bitDepth int64, fn func(int64) bool) bool {
+	if len(rings) == 0 || len(rings[0]) == 0 {
+		return true
+	}
+
+	minLat, minLng, maxLat, maxLng := pointsBbox(rings[0])
+	minLatIdx, minLngIdx := deinterleave(EncodeInt(minLat, minLng, bitDepth), bitDepth)
+	maxLatIdx, maxLngIdx := deinterleave(EncodeInt(maxLat, maxLng, bitDepth), bitDepth)
+
+	for latIdx := minLatIdx; latIdx <= maxLatIdx; latIdx++ {
+		rowMinLat, _, rowMaxLat, _ := DecodeBboxInt(interleave(latIdx, 0, bitDepth), bitDepth)
+		edges := ringEdgesBetween(rings, rowMinLat, rowMaxLat)
+
+		for lngIdx := minLngIdx; lngIdx <= maxLngIdx; lngIdx++ {
+			geohash := interleave(latIdx, lngIdx, bitDepth)
+			cellMinLat, cellMinLng, cellMaxLat, cellMaxLng := DecodeBboxInt(geohash, bitDepth)
+
+			// a cell is covered when the boundary passes through it or it is entirely inside the polygon
+			covered := false
+			for _, edge := range edges {
+				if segmentCrossesRect(edge[0], edge[1], cellMinLat, cellMinLng, cellMaxLat, cellMaxLng) {
+					covered = true
+					break
+				}
+			}
+			if !covered {
+				covered = ringsContain(rings, (cellMinLat+cellMaxLat)/2, (cellMinLng+cellMaxLng)/2)
+			}
+
+			if covered && !fn(geohash) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ringEdgesBetween returns the edges of all rings that overlap the latitude range minLat to maxLat.
+func ringEdgesBetween(rings [][]Point, minLat float64, maxLat float64) [][2]Point {
+	var output [][2]Point
+	for _, ring := range rings {
+		for index := range ring {
+			from := ring[index]
+			to := ring[(index+1)%len(ring)]
+			if math.Max(from.Lat, to.Lat) < minLat || math.Min(from.Lat, to.Lat) > maxLat {
+				continue
+			}
+			output = append(output, [2]Point{from, to})
+		}
+	}
+	return output
+}
+
+// ringsContain returns true when the point is inside the outer ring and outside all of the holes.
+func ringsContain(rings [][]Point, lat float64, lng float64) bool {
+	if !ringContains(rings[0], lat, lng) {
+		return false
+	}
+	for _, hole := range rings[1:] {
+		if ringContains(hole, lat, lng) {
+			return false
+		}
+	}
+	return true
+}
+
+// ringContains returns true when the point is inside the ring using the even-odd (ray casting) rule.
+//
+// The ring may be open or closed (first point repeated as the last).
+func ringContains(ring []Point, lat float64, lng float64) bool {
+	inside := false
+	for index, previous := 0, len(ring)-1; index < len(ring); previous, index = index, index+1 {
+		a := ring[index]
+		b := ring[previous]
+		if (a.Lat > lat) != (b.Lat > lat) && lng < (b.Lng-a.Lng)*(lat-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// pointsBbox returns the bounding box of a set of points.
+func pointsBbox(points []Point) (minLat float64, minLng float64, maxLat float64, maxLng float64) {
+	minLat, minLng = math.Inf(1), math.Inf(1)
+	maxLat, maxLng = math.Inf(-1), math.Inf(-1)
+	for _, point := range points {
+		minLat = math.Min(minLat, point.Lat)
+		minLng = math.Min(minLng, point.Lng)
+		maxLat = math.Max(maxLat, point.Lat)
+		maxLng = math.Max(maxLng, point.Lng)
+	}
+	return
+}
+
+// clipSegment clips the segment p1 to p2 against a rectangle using the Liang-Barsky algorithm.
+//
+// Returns the fractions along the segment where it enters and leaves the (closed) rectangle, and false when the
+// segment misses the rectangle entirely.
+func clipSegment(p1 Point, p2 Point, minLat float64, minLng float64, maxLat float64, maxLng float64) (t0 float64, t1 float64, ok bool) {
+	t0, t1 = 0, 1
+	deltaLng := p2.Lng - p1.Lng
+	deltaLat := p2.Lat - p1.Lat
+
+	clip := func(p float64, q float64) bool {
+		if p == 0 {
+			// parallel to this edge, so either entirely inside or outside of it
+			return q >= 0
+		}
+		r := q / p
+		if p < 0 {
+			if r > t1 {
+				return false
+			}
+			t0 = math.Max(t0, r)
+		} else {
+			if r < t0 {
+				return false
+			}
+			t1 = math.Min(t1, r)
+		}
+		return true
+	}
+
+	ok = clip(-deltaLng, p1.Lng-minLng) &&
+		clip(deltaLng, maxLng-p1.Lng) &&
+		clip(-deltaLat, p1.Lat-minLat) &&
+		clip(deltaLat, maxLat-p1.Lat)
+	return
+}
+
+// segmentCrossesRect returns true when the segment p1 to p2 passes through the interior of the rectangle.
+//
+// Segments that only run along or touch the rectangle's edges do not count.
+func segmentCrossesRect(p1 Point, p2 Point, minLat float64, minLng float64, maxLat float64, maxLng float64) bool {
+	t0, t1, ok := clipSegment(p1, p2, minLat, minLng, maxLat, maxLng)
+	if !ok {
+		return false
+	}
+
+	// the clipped segment lies within the closed rectangle, so its midpoint is only on the edge when all of it is
+	mid := (t0 + t1) / 2
+	lat := p1.Lat + (p2.Lat-p1.Lat)*mid
+	lng := p1.Lng + (p2.Lng-p1.Lng)*mid
+	return lat > minLat && lat < maxLat && lng > minLng && lng < maxLng
+}
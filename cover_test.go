@@ -0,0 +1,243 @@
+package geohash
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCoverPolygonInt(t *testing.T) {
+	// a triangle covering the south west half of a 2x2 block of depth 4 cells
+	polygon := []Point{
+		{Lat: -90, Lng: -180},
+		{Lat: -90, Lng: 0},
+		{Lat: 0, Lng: -180},
+	}
+
+	results := CoverPolygonInt(polygon, 4)
+	expected := []int64{0, 1, 2}
+	if len(results) != len(expected) {
+		t.Fatalf("Expected %+v but was %+v", expected, results)
+	}
+	for index := range expected {
+		if expected[index] != results[index] {
+			t.Errorf("Expected %+v but was %+v", expected, results)
+		}
+	}
+}
+
+func TestCoverRadiusInt(t *testing.T) {
+	results := CoverRadiusInt(-37.8136, 144.9631, 1000, 30)
+	assertSortedUnique(t, results)
+
+	for _, geohash := range results {
+		minLat, minLng, maxLat, maxLng := DecodeBboxInt(geohash, 30)
+		if distance := distanceToRect(Point{Lat: -37.8136, Lng: 144.9631}, minLat, minLng, maxLat, maxLng); distance > 1000 {
+			t.Errorf("Expected %+v to be within %+v but was %+v", geohash, 1000, distance)
+		}
+	}
+	if !toSet(results)[EncodeInt(-37.8136, 144.9631, 30)] {
+		t.Errorf("Expected the center cell to be included")
+	}
+}
+
+func TestCoverCapInt(t *testing.T) {
+	scenarios := []struct {
+		desc      string
+		axis      Point
+		halfAngle float64
+	}{
+		{desc: "small", axis: Point{Lat: -37.8136, Lng: 144.9631}, halfAngle: 5},
+		{desc: "antimeridian", axis: Point{Lat: 10, Lng: 179}, halfAngle: 30},
+		{desc: "larger than a hemisphere", axis: Point{Lat: 20, Lng: 30}, halfAngle: 120},
+		{desc: "south pole", axis: Point{Lat: -90, Lng: 0}, halfAngle: 40},
+	}
+
+	for _, scenario := range scenarios {
+		result := CoverCapInt(scenario.axis, scenario.halfAngle, 8)
+		assertSortedUnique(t, result)
+		included := toSet(result)
+
+		// sample each cell, allowing for the spacing of the samples when checking cells are not too far away
+		for geohash := int64(0); geohash < 1<<8; geohash++ {
+			minLat, minLng, maxLat, maxLng := DecodeBboxInt(geohash, 8)
+			nearest := math.Inf(1)
+			for i := 0.0; i <= 20; i++ {
+				for j := 0.0; j <= 20; j++ {
+					lat := minLat + (maxLat-minLat)*i/20
+					lng := minLng + (maxLng-minLng)*j/20
+					nearest = math.Min(nearest, Distance(scenario.axis.Lat, scenario.axis.Lng, lat, lng))
+				}
+			}
+			nearest = nearest / EarthRadiusMeters * 180 / math.Pi
+
+			if nearest <= scenario.halfAngle && !included[geohash] {
+				t.Errorf("%s: Expected %+v to be included", scenario.desc, geohash)
+			}
+			if nearest > scenario.halfAngle+1 && included[geohash] {
+				t.Errorf("%s: Expected %+v to be excluded but was %+v degrees away", scenario.desc, geohash, nearest)
+			}
+		}
+	}
+}
+
+func TestCoverCapIntEdgeCases(t *testing.T) {
+	// the top two rows of 5.625 degrees reach down past 80 degrees
+	result := CoverCapInt(Point{Lat: 90, Lng: 0}, 10, 10)
+	if len(result) != 64 {
+		t.Errorf("Expected %+v but was %+v", 64, len(result))
+	}
+	for _, geohash := range result {
+		if _, _, maxLat, _ := DecodeBboxInt(geohash, 10); maxLat < 80 {
+			t.Errorf("Expected %+v to reach %+v but was %+v", geohash, 80, maxLat)
+		}
+	}
+
+	if result := CoverCapInt(Point{Lat: 12, Lng: -45}, 180, 10); len(result) != 1<<10 {
+		t.Errorf("Expected %+v but was %+v", 1<<10, len(result))
+	}
+
+	expected := []int64{EncodeInt(37.8324, 112.5584, 40)}
+	if result := CoverCapInt(Point{Lat: 37.8324, Lng: 112.5584}, 0, 40); !reflect.DeepEqual(expected, result) {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
+
+func TestCoverCirclesInt(t *testing.T) {
+	centers := []Point{{Lat: 37.8324, Lng: 112.5584}, {Lat: 37.84, Lng: 112.56}, {Lat: 38.5, Lng: 113}}
+	radii := []float64{2000, 1500, 500}
+
+	expected := map[int64]bool{}
+	for index, center := range centers {
+		for _, geohash := range CoverRadiusInt(center.Lat, center.Lng, radii[index], 30) {
+			expected[geohash] = true
+		}
+	}
+
+	result := CoverCirclesInt(centers, radii, 30)
+	assertSortedUnique(t, result)
+	if !reflect.DeepEqual(expected, toSet(result)) || len(expected) != len(result) {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+
+	if result := CoverCirclesInt(nil, nil, 30); len(result) != 0 {
+		t.Errorf("Expected no hashes but was %+v", result)
+	}
+}
+
+func TestCoverCirclesRadiusInt(t *testing.T) {
+	centers := []Point{{Lat: 37.8324, Lng: 112.5584}, {Lat: 37.84, Lng: 112.56}}
+	expected := CoverCirclesInt(centers, []float64{1000, 1000}, 30)
+	if result := CoverCirclesRadiusInt(centers, 1000, 30); !reflect.DeepEqual(expected, result) {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
+
+func TestCoverCirclesIntMismatchedLengths(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic")
+		}
+	}()
+	CoverCirclesInt([]Point{{Lat: 1, Lng: 1}}, nil, 30)
+}
+
+func TestCoverRotatedRectInt(t *testing.T) {
+	center := Point{Lat: 37.8324, Lng: 112.5584}
+	expected := CoverRotatedRectInt(center, 2000, 500, 30, 40)
+	assertSortedUnique(t, expected)
+
+	// rotations wrap around 360 degrees and a half turn gives the same rectangle
+	for _, rotation := range []float64{390, -330, 210, 750} {
+		if result := CoverRotatedRectInt(center, 2000, 500, rotation, 40); !reflect.DeepEqual(expected, result) {
+			t.Errorf("%+v: Expected %+v but was %+v", rotation, expected, result)
+		}
+	}
+
+	// a quarter turn swaps the width and height
+	if result, swapped := CoverRotatedRectInt(center, 2000, 500, 90, 40), CoverRotatedRectInt(center, 500, 2000, 0, 40); !reflect.DeepEqual(swapped, result) {
+		t.Errorf("Expected %+v but was %+v", swapped, result)
+	}
+
+	// points inside the rectangle are covered while those just outside of it are not
+	covered := toSet(expected)
+	for _, scenario := range []struct {
+		bearing  float64
+		distance float64
+		inside   bool
+	}{
+		{bearing: 30, distance: 0, inside: true},
+		{bearing: 30, distance: 200, inside: true},
+		{bearing: 120, distance: 900, inside: true},
+		{bearing: 300, distance: 900, inside: true},
+		{bearing: 30, distance: 600, inside: false},
+		{bearing: 120, distance: 1300, inside: false},
+	} {
+		lat, lng := DestinationPoint(center.Lat, center.Lng, scenario.bearing, scenario.distance)
+		if result := covered[EncodeInt(lat, lng, 40)]; scenario.inside != result {
+			t.Errorf("%+v: Expected %+v but was %+v", scenario, scenario.inside, result)
+		}
+	}
+}
+
+func TestCoverRadiusAdaptiveInt(t *testing.T) {
+	scenarios := []struct {
+		desc     string
+		lat      float64
+		lng      float64
+		radius   float64
+		maxCells int
+	}{
+		{desc: "city", lat: -37.8136, lng: 144.9631, radius: 5000, maxCells: 8},
+		{desc: "many cells", lat: 51.5007, lng: -0.1246, radius: 1000, maxCells: 64},
+		{desc: "one cell", lat: 37.8324, lng: 112.5584, radius: 100, maxCells: 1},
+		{desc: "antimeridian", lat: 0, lng: 179.99, radius: 5000, maxCells: 16},
+		{desc: "pole", lat: 89.99, lng: 0, radius: 5000, maxCells: 16},
+		{desc: "high latitude", lat: 70, lng: 10, radius: 1000000, maxCells: 50},
+		{desc: "high latitude many cells", lat: 75, lng: 30, radius: 800000, maxCells: 200},
+		{desc: "southern high latitude", lat: -72, lng: -170, radius: 1500000, maxCells: 100},
+	}
+
+	for _, scenario := range scenarios {
+		result := CoverRadiusAdaptiveInt(scenario.lat, scenario.lng, scenario.radius, scenario.maxCells)
+		if len(result) == 0 || len(result) > scenario.maxCells && len(result) > 4 {
+			t.Errorf("%s: Expected at most %+v cells but was %+v", scenario.desc, scenario.maxCells, len(result))
+		}
+
+		// points throughout the circle, including on its edge, are all inside one of the covering's cells
+		for bearing := 0.0; bearing < 360; bearing++ {
+			for _, fraction := range []float64{0, 0.5, 0.9, 0.99, 0.999, 0.999999} {
+				lat, lng := DestinationPoint(scenario.lat, scenario.lng, bearing, scenario.radius*fraction)
+
+				covered := false
+				for _, hash := range result {
+					covered = covered || CellContainsPoint(hash.Value, hash.BitDepth, lat, lng)
+				}
+				if !covered {
+					t.Errorf("%s: Expected %+v,%+v to be covered by %+v", scenario.desc, lat, lng, result)
+				}
+			}
+		}
+	}
+}
+
+func TestCoverRadiusAdaptiveIntRefines(t *testing.T) {
+	coarse := CoverRadiusAdaptiveInt(-37.8136, 144.9631, 5000, 4)
+	fine := CoverRadiusAdaptiveInt(-37.8136, 144.9631, 5000, 64)
+
+	var coarseArea, fineArea float64
+	for _, hash := range coarse {
+		coarseArea += CellAreaSqMeters(hash.Value, hash.BitDepth)
+	}
+	for _, hash := range fine {
+		fineArea += CellAreaSqMeters(hash.Value, hash.BitDepth)
+	}
+	if fineArea >= coarseArea || len(fine) <= len(coarse) {
+		t.Errorf("Expected more cells (%+v) to cover less area (%+v) than %+v cells (%+v)", len(fine), fineArea, len(coarse), coarseArea)
+	}
+
+	// the covering is never smaller than the circle
+	if circle := math.Pi * 5000 * 5000; fineArea < circle {
+		t.Errorf("Expected an area of at least %+v but was %+v", circle, fineArea)
+	}
+}
@@ -0,0 +1,332 @@
+package geohash
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// DiffCoverings will compare two coverings (sets of geohash integers at the same bitDepth) and return the cells that
+// are only in current (added) and the cells that are only in previous (removed).
+//
+// The order of the inputs is ignored and duplicates are allowed. Both outputs are sorted ascending.
+func DiffCoverings(previous []int64, current []int64) (added []int64, removed []int64) {
+	previousSet := toSet(previous)
+	currentSet := toSet(current)
+
+	for geohash := range currentSet {
+		if !previousSet[geohash] {
+			added = append(added, geohash)
+		}
+	}
+	for geohash := range previousSet {
+		if !currentSet[geohash] {
+			removed = append(removed, geohash)
+		}
+	}
+
+	sortHashes(added)
+	sortHashes(removed)
+	return
+}
+
+// ResampleCovering will convert a covering (a set of geohash integers at fromDepth) into a covering of the same area
+// at toDepth.
+//
+// When downsampling (toDepth < fromDepth) each cell is truncated to the coarser cell that contains it, so the result
+// covers at least the original area and a single fine cell is enough to include its whole parent. When upsampling
+// (toDepth > fromDepth) each cell is expanded into all of its descendants, which multiplies the number of cells by 4
+// for every 2 bits; see ResampleCoveringLimit to guard against this. The result is sorted ascending without
+// duplicates.
+func ResampleCovering(hashes []int64, fromDepth int64, toDepth int64) []int64 {
+	output, _ := resampleCovering(hashes, fromDepth, toDepth, -1)
+	return output
+}
+
+// ResampleCoveringLimit will convert a covering as ResampleCovering does but returns an error, without expanding any
+// cells, when the result would contain more than maxCells cells.
+func ResampleCoveringLimit(hashes []int64, fromDepth int64, toDepth int64, maxCells int) ([]int64, error) {
+	return resampleCovering(hashes, fromDepth, toDepth, maxCells)
+}
+
+// resampleCovering implements ResampleCovering, with a negative maxCells meaning there is no limit.
+func resampleCovering(hashes []int64, fromDepth int64, toDepth int64, maxCells int) ([]int64, error) {
+	// input validation
+	validateBitDepth(fromDepth)
+	validateBitDepth(toDepth)
+
+	if toDepth <= fromDepth {
+		shift := uint64(fromDepth - toDepth)
+		output := make([]int64, 0, len(hashes))
+		for _, geohash := range hashes {
+			output = append(output, geohash>>shift)
+		}
+		return NormalizeHashes(output), nil
+	}
+
+	// distinct cells have distinct descendants so the size of the result is known up front
+	cells := toSet(hashes)
+	shift := uint64(toDepth - fromDepth)
+	if maxCells >= 0 && uint64(len(cells)) > uint64(maxCells)>>shift {
+		return nil, fmt.Errorf("resampling %d cells from bitDepth %d to %d exceeds the limit of %d cells", len(cells), fromDepth, toDepth, maxCells)
+	}
+
+	output := make([]int64, 0, len(cells)<<shift)
+	for geohash := range cells {
+		for descendant := geohash << shift; descendant < (geohash+1)<<shift; descendant++ {
+			output = append(output, descendant)
+		}
+	}
+
+	sortHashes(output)
+	return output, nil
+}
+
+// IsContiguous will return true when the cells of a covering (geohash integers at bitDepth) form a single connected
+// region, with cells either side of the antimeridian being connected.
+//
+// A connectivity of 4 only connects cells that share an edge (N, E, S and W) while 8 also connects cells that share
+// a corner; any other value will cause panic(). An empty covering is contiguous and duplicates are ignored.
+func IsContiguous(hashes []int64, bitDepth int64, connectivity int) bool {
+	// input validation
+	validateBitDepth(bitDepth)
+	steps := connectivitySteps(connectivity)
+
+	cells := toSet(hashes)
+	if len(cells) == 0 {
+		return true
+	}
+
+	// flood fill from any one cell
+	queue := []int64{hashes[0]}
+	seen := map[int64]bool{hashes[0]: true}
+	for len(queue) > 0 {
+		geohash := queue[0]
+		queue = queue[1:]
+
+		for _, step := range steps {
+			neighbor, ok := stepCell(geohash, step[0], step[1], bitDepth)
+			if ok && cells[neighbor] && !seen[neighbor] {
+				seen[neighbor] = true
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+	return len(seen) == len(cells)
+}
+
+// BoundaryCells will return the cells of a covering (geohash integers at bitDepth) that have at least one edge (N, E, S
+// or W) neighbor that is not in the covering, which is the outline of the region.
+//
+// Cells either side of the antimeridian are neighbors. Cells in the northernmost or southernmost row have no neighbor
+// beyond the pole, which counts as not being in the covering. The output is sorted ascending without duplicates.
+func BoundaryCells(hashes []int64, bitDepth int64) []int64 {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	cells := toSet(hashes)
+	var output []int64
+	for geohash := range cells {
+		for _, step := range connectivitySteps(4) {
+			neighbor, ok := stepCell(geohash, step[0], step[1], bitDepth)
+			if !ok || !cells[neighbor] {
+				output = append(output, geohash)
+				break
+			}
+		}
+	}
+
+	sortHashes(output)
+	return output
+}
+
+// PathWithinCovering will return true when every cell at bitDepth that a path passes through is in the covering.
+//
+// The cells between consecutive points are found with LineHashesInt, so a path can not jump over a gap in the
+// covering between two points that are both inside it. When the path leaves the covering the index of the first point
+// that it does not reach without leaving is returned too, which is the first point itself when that is outside and
+// otherwise the end of the first leg that leaves. An empty path is within any covering. Returns -1 as the index when
+// the path stays within the covering.
+func PathWithinCovering(points []Point, hashes []int64, bitDepth int64) (bool, int) {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	cells := toSet(hashes)
+	for index, point := range points {
+		if index == 0 {
+			if !cells[EncodeInt(point.Lat, point.Lng, bitDepth)] {
+				return false, 0
+			}
+			continue
+		}
+
+		previous := points[index-1]
+		for _, geohash := range LineHashesInt(previous.Lat, previous.Lng, point.Lat, point.Lng, bitDepth) {
+			if !cells[geohash] {
+				return false, index
+			}
+		}
+	}
+	return true, -1
+}
+
+// NearestInt will return the cell of hashes that is closest to the supplied point and the great-circle distance in
+// meters from the point to the nearest edge of that cell, which is 0 when the point is within it.
+//
+// When several cells are equally close the first of them in hashes is returned. An empty hashes returns 0 with an
+// infinite distance.
+func NearestInt(hashes []int64, lat float64, lng float64, bitDepth int64) (int64, float64) {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	point := Point{Lat: lat, Lng: lng}
+	var nearest int64
+	nearestDistance := math.Inf(1)
+	for _, geohash := range hashes {
+		minLat, minLng, maxLat, maxLng := DecodeBboxInt(geohash, bitDepth)
+		if distance := distanceToRect(point, minLat, minLng, maxLat, maxLng); distance < nearestDistance {
+			nearest, nearestDistance = geohash, distance
+		}
+	}
+	return nearest, nearestDistance
+}
+
+// NearestBoundaryCellInt will return the cell on the boundary of a covering (see BoundaryCells) that is closest to the
+// supplied point and the distance in meters from the point to it, as NearestInt does.
+//
+// A point inside the covering gets the boundary cell nearest to it, so the distance is 0 only when the point is in a
+// boundary cell and is otherwise the distance from the point to the edge of the covering's boundary ring. An empty
+// covering returns 0 with an infinite distance.
+func NearestBoundaryCellInt(hashes []int64, lat float64, lng float64, bitDepth int64) (int64, float64) {
+	return NearestInt(BoundaryCells(hashes, bitDepth), lat, lng, bitDepth)
+}
+
+// CoveringMorphSteps will return steps coverings (geohash integers at bitDepth) that change from one covering into
+// another, such as for animating a change to a geofence, with the last being to.
+//
+// The morph grows from into to while eroding the parts of from that are not in to. Each cell only in to is added once
+// the fraction of the steps taken reaches its distance (in cells, through the cells of either covering) from from,
+// relative to the furthest such cell, and each cell only in from is removed in the same way by its distance from to,
+// so the growth advances out from the shared edge and the erosion retreats towards it. Parts of a covering that are
+// not connected to the other covering through either of them can not be reached, so they jump at the last step
+// instead: disjoint coverings stay as from until the whole of to replaces them at once. Cells either side of the
+// antimeridian are connected. Each covering is sorted ascending without duplicates. Panics when steps is less than 1.
+func CoveringMorphSteps(from []int64, to []int64, bitDepth int64, steps int) [][]int64 {
+	// input validation
+	validateBitDepth(bitDepth)
+	if steps < 1 {
+		panic(fmt.Sprintf("steps must be at least 1, was %d", steps))
+	}
+
+	fromCells := toSet(from)
+	toCells := toSet(to)
+	union := toSet(append(append([]int64{}, from...), to...))
+	grow, maxGrow := morphDistances(fromCells, toCells, union, bitDepth)
+	erode, maxErode := morphDistances(toCells, fromCells, union, bitDepth)
+
+	output := make([][]int64, 0, steps)
+	for step := 1; step <= steps; step++ {
+		var covering []int64
+		for geohash := range union {
+			switch {
+			case fromCells[geohash] && toCells[geohash]:
+				covering = append(covering, geohash)
+
+			case toCells[geohash]:
+				if distance, found := grow[geohash]; step == steps || found && distance*steps <= step*maxGrow {
+					covering = append(covering, geohash)
+				}
+
+			default:
+				if distance, found := erode[geohash]; step < steps && (!found || distance*steps <= (steps-step)*maxErode) {
+					covering = append(covering, geohash)
+				}
+			}
+		}
+
+		sortHashes(covering)
+		output = append(output, covering)
+	}
+	return output
+}
+
+// morphDistances returns the distance in cells from the nearest of the sources to each of the targets that are not
+// sources themselves, moving (with 8 connectivity) only through cells in within, and the furthest of those distances.
+//
+// Targets that can not be reached are not included.
+func morphDistances(sources map[int64]bool, targets map[int64]bool, within map[int64]bool, bitDepth int64) (map[int64]int, int) {
+	queue := make([]int64, 0, len(sources))
+	distances := map[int64]int{}
+	for geohash := range sources {
+		queue = append(queue, geohash)
+		distances[geohash] = 0
+	}
+
+	output := map[int64]int{}
+	furthest := 0
+	for len(queue) > 0 {
+		geohash := queue[0]
+		queue = queue[1:]
+
+		for _, step := range connectivitySteps(8) {
+			neighbor, ok := stepCell(geohash, step[0], step[1], bitDepth)
+			if _, seen := distances[neighbor]; !ok || seen || !within[neighbor] {
+				continue
+			}
+			distance := distances[geohash] + 1
+			distances[neighbor] = distance
+			queue = append(queue, neighbor)
+
+			if targets[neighbor] {
+				output[neighbor] = distance
+				if distance > furthest {
+					furthest = distance
+				}
+			}
+		}
+	}
+	return output, furthest
+}
+
+// connectivitySteps returns the lat and lng cell steps to each neighbor for 4 or 8 connectivity or causes panic().
+func connectivitySteps(connectivity int) [][2]int64 {
+	switch connectivity {
+	case 4:
+		return [][2]int64{{1, 0}, {0, 1}, {-1, 0}, {0, -1}}
+	case 8:
+		return [][2]int64{{1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}, {0, -1}, {1, -1}}
+	default:
+		panic(fmt.Sprintf("connectivity must be 4 or 8, was %d", connectivity))
+	}
+}
+
+// NormalizeHashes will sort a slice of geohash integers ascending and remove any duplicates, in place.
+//
+// The returned slice shares the underlying array of hashes, whose contents beyond the returned length are undefined.
+func NormalizeHashes(hashes []int64) []int64 {
+	sortHashes(hashes)
+
+	output := hashes[:0]
+	for index, geohash := range hashes {
+		if index == 0 || geohash != hashes[index-1] {
+			output = append(output, geohash)
+		}
+	}
+	return output
+}
+
+// toSet converts a slice of geohash integers into a set.
+func toSet(hashes []int64) map[int64]bool {
+	output := make(map[int64]bool, len(hashes))
+	for _, geohash := range hashes {
+		output[geohash] = true
+	}
+	return output
+}
+
+// sortHashes sorts a slice of geohash integers ascending in place.
+func sortHashes(hashes []int64) {
+	sort.Slice(hashes, func(i, j int) bool {
+		return hashes[i] < hashes[j]
+	})
+}
@@ -0,0 +1,63 @@
+package geohash
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteCovering will write a covering (geohash integers at bitDepth) to w as base32 geohash strings, one per line.
+//
+// As with Hash.MarshalText only the bit depths that ParseHash returns can be written so that ReadCovering gives back
+// the same covering; other bit depths return an error before anything is written. Any covering that ReadCovering
+// returns can be written back as the same lines.
+func WriteCovering(w io.Writer, hashes []int64, bitDepth int64) error {
+	// input validation
+	if _, err := (Hash{BitDepth: bitDepth}).MarshalText(); err != nil {
+		return err
+	}
+
+	buffered := bufio.NewWriter(w)
+	for _, geohash := range hashes {
+		text, err := Hash{Value: geohash, BitDepth: bitDepth}.MarshalText()
+		if err != nil {
+			return err
+		}
+		buffered.Write(text)
+		buffered.WriteByte('\n')
+	}
+	return buffered.Flush()
+}
+
+// ReadCovering will read a covering written by WriteCovering from r, returning the geohash integers and their bitDepth.
+//
+// Blank lines and surrounding whitespace are ignored and the final line does not need to end with a newline. An error
+// is returned for an invalid geohash or when the lines are not all the same length (and so bitDepth). An empty
+// input returns no hashes and a bitDepth of 0.
+func ReadCovering(r io.Reader) ([]int64, int64, error) {
+	var output []int64
+	var bitDepth int64
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var hash Hash
+		if err := hash.UnmarshalText([]byte(text)); err != nil {
+			return nil, 0, fmt.Errorf("line %d: %s", line, err)
+		}
+		if bitDepth != 0 && hash.BitDepth != bitDepth {
+			return nil, 0, fmt.Errorf("line %d: geohash %q has bitDepth %d but the covering has bitDepth %d", line, text, hash.BitDepth, bitDepth)
+		}
+		bitDepth = hash.BitDepth
+		output = append(output, hash.Value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, err
+	}
+	return output, bitDepth, nil
+}
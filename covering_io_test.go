@@ -0,0 +1,81 @@
+package geohash
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteReadCovering(t *testing.T) {
+	scenarios := []struct {
+		desc     string
+		hashes   []int64
+		bitDepth int64
+		chars    int
+	}{
+		{desc: "30 bits", hashes: BboxesInt(57.64, 10.40, 57.65, 10.41, 30), bitDepth: 30, chars: 6},
+		{desc: "44 bits", hashes: NeighborsInt(EncodeInt(57.64911, 10.40744, 44), 44), bitDepth: 44, chars: 9},
+		{desc: "60 bits", hashes: NeighborsInt(EncodeInt(57.64911, 10.40744, 60), 60), bitDepth: 60, chars: 12},
+		{desc: "max bit depth", hashes: NeighborsInt(EncodeInt(57.64911, 10.40744, MaxBitDepth), MaxBitDepth), bitDepth: MaxBitDepth, chars: 11},
+	}
+
+	for _, scenario := range scenarios {
+		var buffer bytes.Buffer
+		if err := WriteCovering(&buffer, scenario.hashes, scenario.bitDepth); err != nil {
+			t.Fatalf("%s: Unexpected error %v", scenario.desc, err)
+		}
+		if lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n"); len(lines) != len(scenario.hashes) || len(lines[0]) != scenario.chars {
+			t.Errorf("%s: Expected %+v lines of %+v characters but was %+v", scenario.desc, len(scenario.hashes), scenario.chars, lines)
+		}
+
+		result, bitDepth, err := ReadCovering(&buffer)
+		if err != nil || bitDepth != scenario.bitDepth || !reflect.DeepEqual(scenario.hashes, result) {
+			t.Errorf("%s: Expected %+v but was %+v at %+v (%v)", scenario.desc, scenario.hashes, result, bitDepth, err)
+		}
+	}
+}
+
+func TestReadWriteCoveringOddLength(t *testing.T) {
+	input := "ww8p1r4t8\nww8p1r4tb\n"
+	hashes, bitDepth, err := ReadCovering(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	var buffer bytes.Buffer
+	if err := WriteCovering(&buffer, hashes, bitDepth); err != nil || buffer.String() != input {
+		t.Errorf("Expected %q but was %q (%v)", input, buffer.String(), err)
+	}
+}
+
+func TestWriteCoveringUnsupportedBitDepth(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := WriteCovering(&buffer, []int64{1}, 26); err == nil || buffer.Len() != 0 {
+		t.Errorf("Expected an error and no output but was %q (%v)", buffer.String(), err)
+	}
+}
+
+func TestReadCovering(t *testing.T) {
+	// blank lines, whitespace and a partial final line
+	result, bitDepth, err := ReadCovering(strings.NewReader("u4pruy\n\n  u4pruz \r\nu4prvb"))
+	var expected []int64
+	for _, text := range []string{"u4pruy", "u4pruz", "u4prvb"} {
+		geohash, _, _ := ParseHash(text, "")
+		expected = append(expected, geohash)
+	}
+	if err != nil || bitDepth != 30 || !reflect.DeepEqual(expected, result) {
+		t.Errorf("Expected %+v but was %+v at %+v (%v)", expected, result, bitDepth, err)
+	}
+
+	result, bitDepth, err = ReadCovering(strings.NewReader(""))
+	if err != nil || bitDepth != 0 || len(result) != 0 {
+		t.Errorf("Expected nothing but was %+v at %+v (%v)", result, bitDepth, err)
+	}
+
+	for _, input := range []string{"u4pruy\nu4pa", "u4pruy\nu4pr", "ww8p1r4t9"} {
+		if _, _, err := ReadCovering(strings.NewReader(input)); err == nil {
+			t.Errorf("Expected an error for %q", input)
+		}
+	}
+}
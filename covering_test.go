@@ -0,0 +1,376 @@
+package geohash
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestDiffCoverings(t *testing.T) {
+	previous := []int64{5, 1, 3, 3, 7}
+	current := []int64{9, 3, 2, 7, 2}
+
+	added, removed := DiffCoverings(previous, current)
+	if expected := []int64{2, 9}; !reflect.DeepEqual(expected, added) {
+		t.Errorf("Expected %+v but was %+v", expected, added)
+	}
+	if expected := []int64{1, 5}; !reflect.DeepEqual(expected, removed) {
+		t.Errorf("Expected %+v but was %+v", expected, removed)
+	}
+}
+
+func TestDiffCoveringsUnchanged(t *testing.T) {
+	covering := BboxesInt(30, 120, 30.01, 120.01, 30)
+
+	added, removed := DiffCoverings(covering, covering)
+	if len(added) != 0 || len(removed) != 0 {
+		t.Errorf("Expected no differences but was %+v and %+v", added, removed)
+	}
+
+	added, removed = DiffCoverings(nil, covering)
+	if len(added) != len(covering) || len(removed) != 0 {
+		t.Errorf("Expected all cells to be added but was %+v and %+v", added, removed)
+	}
+}
+
+func TestResampleCovering(t *testing.T) {
+	scenarios := []struct {
+		desc      string
+		hashes    []int64
+		fromDepth int64
+		toDepth   int64
+		expected  []int64
+	}{
+		{
+			desc:      "downsample",
+			hashes:    []int64{0x13, 0x10, 0x2f, 0x11},
+			fromDepth: 8,
+			toDepth:   4,
+			expected:  []int64{0x1, 0x2},
+		},
+		{
+			desc:      "upsample",
+			hashes:    []int64{0x3, 0x1, 0x3},
+			fromDepth: 4,
+			toDepth:   6,
+			expected:  []int64{0x4, 0x5, 0x6, 0x7, 0xc, 0xd, 0xe, 0xf},
+		},
+		{
+			desc:      "unchanged",
+			hashes:    []int64{0x7, 0x3, 0x7},
+			fromDepth: 4,
+			toDepth:   4,
+			expected:  []int64{0x3, 0x7},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		result := ResampleCovering(scenario.hashes, scenario.fromDepth, scenario.toDepth)
+		if !reflect.DeepEqual(scenario.expected, result) {
+			t.Errorf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected, result)
+		}
+	}
+}
+
+func TestResampleCoveringRoundTrip(t *testing.T) {
+	covering := BboxesInt(30, 120, 30.1, 120.1, 26)
+	sortHashes(covering)
+
+	result := ResampleCovering(ResampleCovering(covering, 26, 30), 30, 26)
+	if !reflect.DeepEqual(covering, result) {
+		t.Errorf("Expected %+v but was %+v", covering, result)
+	}
+}
+
+func TestResampleCoveringLimit(t *testing.T) {
+	hashes := []int64{0x1, 0x2}
+
+	result, err := ResampleCoveringLimit(hashes, 4, 8, 32)
+	if err != nil || len(result) != 32 {
+		t.Errorf("Expected 32 cells but was %+v (%v)", len(result), err)
+	}
+
+	result, err = ResampleCoveringLimit(hashes, 4, 8, 31)
+	if err == nil || result != nil {
+		t.Errorf("Expected an error but was %+v", result)
+	}
+
+	// even an extreme expansion is rejected without overflowing
+	if _, err = ResampleCoveringLimit(hashes, 2, MaxBitDepth64, 1<<20); err == nil {
+		t.Errorf("Expected an error")
+	}
+}
+
+func TestIsContiguous(t *testing.T) {
+	center := EncodeInt(37.8324, 112.5584, 30)
+	north := StepInt(center, 1, 0, 30)
+	northEast := StepInt(center, 1, 1, 30)
+	east := StepInt(center, 0, 1, 30)
+	far := StepInt(center, 5, 5, 30)
+
+	scenarios := []struct {
+		desc         string
+		hashes       []int64
+		connectivity int
+		expected     bool
+	}{
+		{desc: "empty", hashes: nil, connectivity: 4, expected: true},
+		{desc: "single", hashes: []int64{center}, connectivity: 4, expected: true},
+		{desc: "block", hashes: []int64{center, north, northEast, east}, connectivity: 4, expected: true},
+		{desc: "diagonal 4", hashes: []int64{center, northEast}, connectivity: 4, expected: false},
+		{desc: "diagonal 8", hashes: []int64{center, northEast, northEast}, connectivity: 8, expected: true},
+		{desc: "island", hashes: []int64{center, north, far}, connectivity: 8, expected: false},
+		{desc: "antimeridian", hashes: []int64{EncodeInt(0.1, 179.999, 30), EncodeInt(0.1, -179.999, 30)}, connectivity: 4, expected: true},
+	}
+
+	for _, scenario := range scenarios {
+		result := IsContiguous(scenario.hashes, 30, scenario.connectivity)
+		if scenario.expected != result {
+			t.Errorf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected, result)
+		}
+	}
+}
+
+func TestIsContiguousInvalidConnectivity(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic")
+		}
+	}()
+	IsContiguous([]int64{1}, 30, 6)
+}
+
+func TestBoundaryCells(t *testing.T) {
+	// a solid 5x4 rectangle
+	southWest := EncodeInt(37.8324, 112.5584, 30)
+	var rectangle, perimeter []int64
+	for row := 0; row < 4; row++ {
+		for column := 0; column < 5; column++ {
+			geohash := StepInt(southWest, row, column, 30)
+			rectangle = append(rectangle, geohash)
+			if row == 0 || row == 3 || column == 0 || column == 4 {
+				perimeter = append(perimeter, geohash)
+			}
+		}
+	}
+	sortHashes(perimeter)
+
+	result := BoundaryCells(append(rectangle, rectangle[7]), 30)
+	if !reflect.DeepEqual(perimeter, result) {
+		t.Errorf("Expected %+v but was %+v", perimeter, result)
+	}
+
+	result = BoundaryCells([]int64{southWest}, 30)
+	if expected := []int64{southWest}; !reflect.DeepEqual(expected, result) {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+
+	if result = BoundaryCells(nil, 30); len(result) != 0 {
+		t.Errorf("Expected no cells but was %+v", result)
+	}
+}
+
+func TestBoundaryCellsWholeGlobe(t *testing.T) {
+	var hashes []int64
+	for geohash := int64(0); geohash < 256; geohash++ {
+		hashes = append(hashes, geohash)
+	}
+
+	// only the polar rows are on the boundary as longitude wraps
+	result := BoundaryCells(hashes, 8)
+	if len(result) != 32 {
+		t.Errorf("Expected %+v cells but was %+v", 32, len(result))
+	}
+	for _, geohash := range result {
+		if latIdx, _ := deinterleave(geohash, 8); latIdx != 0 && latIdx != 15 {
+			t.Errorf("Expected %+v to be in a polar row", geohash)
+		}
+	}
+}
+
+func TestPathWithinCovering(t *testing.T) {
+	// a 5x5 square with its middle cell missing
+	southWest := EncodeInt(0.01, 0.01, 20)
+	var hashes []int64
+	for row := 0; row < 5; row++ {
+		for column := 0; column < 5; column++ {
+			if row != 2 || column != 2 {
+				hashes = append(hashes, StepInt(southWest, row, column, 20))
+			}
+		}
+	}
+	cell := func(row int, column int) Point {
+		return CellCenter(StepInt(southWest, row, column, 20), 20)
+	}
+
+	scenarios := []struct {
+		desc          string
+		points        []Point
+		expected      bool
+		expectedIndex int
+	}{
+		{desc: "empty", points: nil, expected: true, expectedIndex: -1},
+		{desc: "single point", points: []Point{cell(0, 0)}, expected: true, expectedIndex: -1},
+		{desc: "around the edge", points: []Point{cell(0, 0), cell(0, 4), cell(4, 4), cell(4, 0), cell(0, 0)}, expected: true, expectedIndex: -1},
+		{desc: "starts outside", points: []Point{cell(-1, 0), cell(0, 0)}, expected: false, expectedIndex: 0},
+		{desc: "ends outside", points: []Point{cell(0, 0), cell(0, 4), cell(0, 6)}, expected: false, expectedIndex: 2},
+		{desc: "jumps over the gap", points: []Point{cell(2, 0), cell(2, 4)}, expected: false, expectedIndex: 1},
+		{desc: "in the gap", points: []Point{cell(1, 1), cell(2, 2), cell(3, 3)}, expected: false, expectedIndex: 1},
+	}
+
+	for _, scenario := range scenarios {
+		result, index := PathWithinCovering(scenario.points, hashes, 20)
+		if scenario.expected != result || scenario.expectedIndex != index {
+			t.Errorf("%s: Expected %+v,%+v but was %+v,%+v", scenario.desc, scenario.expected, scenario.expectedIndex, result, index)
+		}
+	}
+}
+
+func TestNearestInt(t *testing.T) {
+	southWest := EncodeInt(0.01, 0.01, 20)
+	hashes := []int64{StepInt(southWest, 0, 2, 20), southWest, StepInt(southWest, 0, 1, 20)}
+	_, _, maxLat, maxLng := DecodeBboxInt(hashes[0], 20)
+
+	scenarios := []struct {
+		desc             string
+		lat              float64
+		lng              float64
+		expected         int64
+		expectedDistance float64
+	}{
+		{desc: "inside", lat: 0.01, lng: 0.01, expected: southWest, expectedDistance: 0},
+		{desc: "north east", lat: maxLat + 1, lng: maxLng + 1, expected: hashes[0], expectedDistance: Distance(maxLat+1, maxLng+1, maxLat, maxLng)},
+		{desc: "north", lat: maxLat + 1, lng: 0.01, expected: southWest, expectedDistance: Distance(maxLat+1, 0.01, maxLat, 0.01)},
+	}
+
+	for _, scenario := range scenarios {
+		result, distance := NearestInt(hashes, scenario.lat, scenario.lng, 20)
+		if scenario.expected != result || math.Abs(scenario.expectedDistance-distance) > 1e-6 {
+			t.Errorf("%s: Expected %+v,%+v but was %+v,%+v", scenario.desc, scenario.expected, scenario.expectedDistance, result, distance)
+		}
+	}
+
+	if result, distance := NearestInt(nil, 1, 1, 20); result != 0 || !math.IsInf(distance, 1) {
+		t.Errorf("Expected %+v,%+v but was %+v,%+v", 0, math.Inf(1), result, distance)
+	}
+}
+
+func TestNearestBoundaryCellInt(t *testing.T) {
+	// a solid 5x5 square
+	southWest := EncodeInt(0.01, 0.01, 20)
+	var square []int64
+	for row := 0; row < 5; row++ {
+		for column := 0; column < 5; column++ {
+			square = append(square, StepInt(southWest, row, column, 20))
+		}
+	}
+
+	// from the middle the nearest edge of the boundary ring is the top of the southern row
+	centerLat, centerLng, _, _ := DecodeInt(StepInt(southWest, 2, 2, 20), 20)
+	_, _, edgeLat, _ := DecodeBboxInt(southWest, 20)
+	result, distance := NearestBoundaryCellInt(square, centerLat, centerLng, 20)
+	if expected, expectedDistance := StepInt(southWest, 0, 2, 20), Distance(centerLat, centerLng, edgeLat, centerLng); expected != result || math.Abs(expectedDistance-distance) > 1e-6 {
+		t.Errorf("Expected %+v,%+v but was %+v,%+v", expected, expectedDistance, result, distance)
+	}
+
+	// on a boundary cell
+	lat, lng, _, _ := DecodeInt(StepInt(southWest, 4, 3, 20), 20)
+	if result, distance := NearestBoundaryCellInt(square, lat, lng, 20); result != StepInt(southWest, 4, 3, 20) || distance != 0 {
+		t.Errorf("Expected %+v,%+v but was %+v,%+v", StepInt(southWest, 4, 3, 20), 0, result, distance)
+	}
+
+	if result, distance := NearestBoundaryCellInt(nil, 1, 1, 20); result != 0 || !math.IsInf(distance, 1) {
+		t.Errorf("Expected %+v,%+v but was %+v,%+v", 0, math.Inf(1), result, distance)
+	}
+}
+
+func TestCoveringMorphSteps(t *testing.T) {
+	center := EncodeInt(37.8324, 112.5584, 30)
+	block := func(size int) []int64 {
+		var output []int64
+		for dLat := -size; dLat <= size; dLat++ {
+			for dLng := -size; dLng <= size; dLng++ {
+				output = append(output, StepInt(center, dLat, dLng, 30))
+			}
+		}
+		return NormalizeHashes(output)
+	}
+	far := EncodeInt(-33.8688, 151.2093, 30)
+
+	scenarios := []struct {
+		desc     string
+		from     []int64
+		to       []int64
+		steps    int
+		expected [][]int64
+	}{
+		{desc: "grow", from: []int64{center}, to: block(2), steps: 2, expected: [][]int64{block(1), block(2)}},
+		{desc: "shrink", from: block(2), to: []int64{center}, steps: 2, expected: [][]int64{block(1), {center}}},
+		{desc: "one step", from: []int64{center}, to: block(2), steps: 1, expected: [][]int64{block(2)}},
+		{desc: "disjoint", from: []int64{center}, to: []int64{far}, steps: 3, expected: [][]int64{{center}, {center}, {far}}},
+		{desc: "unchanged", from: block(1), to: block(1), steps: 2, expected: [][]int64{block(1), block(1)}},
+	}
+
+	for _, scenario := range scenarios {
+		result := CoveringMorphSteps(scenario.from, scenario.to, 30, scenario.steps)
+		if !reflect.DeepEqual(scenario.expected, result) {
+			t.Errorf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected, result)
+		}
+	}
+}
+
+func TestCoveringMorphStepsMoving(t *testing.T) {
+	// a block moving east grows on its east edge while shrinking from its west edge
+	from := BboxesInt(30, 120, 30.05, 120.05, 30)
+	var to []int64
+	for _, geohash := range from {
+		to = append(to, StepInt(geohash, 0, 4, 30))
+	}
+
+	result := CoveringMorphSteps(from, to, 30, 4)
+	if !reflect.DeepEqual(NormalizeHashes(to), result[3]) {
+		t.Errorf("Expected %+v but was %+v", to, result[3])
+	}
+	for index := 1; index < len(result); index++ {
+		previous, _ := UnionBbox(result[index-1], 30)
+		current, _ := UnionBbox(result[index], 30)
+		if current.MinLng < previous.MinLng || current.MaxLng < previous.MaxLng {
+			t.Errorf("Expected step %+v to move east but was %+v after %+v", index, current, previous)
+		}
+	}
+}
+
+func TestCoveringMorphStepsInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic")
+		}
+	}()
+	CoveringMorphSteps([]int64{0}, []int64{1}, 2, 0)
+}
+
+func TestNormalizeHashes(t *testing.T) {
+	scenarios := []struct {
+		desc     string
+		hashes   []int64
+		expected []int64
+	}{
+		{desc: "empty", hashes: nil, expected: nil},
+		{desc: "single", hashes: []int64{5}, expected: []int64{5}},
+		{desc: "unsorted", hashes: []int64{9, 3, 7, 1}, expected: []int64{1, 3, 7, 9}},
+		{desc: "duplicates", hashes: []int64{5, 1, 3, 3, 7, 5, 5}, expected: []int64{1, 3, 5, 7}},
+	}
+
+	for _, scenario := range scenarios {
+		result := NormalizeHashes(scenario.hashes)
+		if len(scenario.expected) != len(result) || len(result) > 0 && !reflect.DeepEqual(scenario.expected, result) {
+			t.Errorf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected, result)
+		}
+	}
+
+	// sorted in place
+	hashes := []int64{3, 1, 2, 1}
+	if result := NormalizeHashes(hashes); &result[0] != &hashes[0] {
+		t.Errorf("Expected the slice to be reused")
+	}
+}
@@ -0,0 +1,136 @@
+package geohash
+
+import (
+	"math"
+)
+
+// Distance will return the great-circle (haversine) distance in meters between two points.
+func Distance(lat1 float64, lng1 float64, lat2 float64, lng2 float64) float64 {
+	return EarthRadiusMeters * 2 * math.Asin(math.Sqrt(haversine(lat1, lng1, lat2, lng2)))
+}
+
+// DistanceInt will return the great-circle distance in meters between the center of a geohash integer's cell and
+// the supplied point.
+func DistanceInt(geohash int64, lat float64, lng float64, bitDepth int64) float64 {
+	centerLat, centerLng, _, _ := DecodeInt(geohash, bitDepth)
+	return Distance(centerLat, centerLng, lat, lng)
+}
+
+// WithinRadiusInt will return true when the supplied point is no more than radiusMeters from the center of a geohash
+// integer's cell, which is the same as DistanceInt(center, lat, lng, bitDepth) <= radiusMeters.
+//
+// It is intended as the inner loop of a radius search so it avoids the square root and arc sine of DistanceInt
+// unless the point is within rounding error of the radius.
+func WithinRadiusInt(center int64, lat float64, lng float64, radiusMeters float64, bitDepth int64) bool {
+	centerLat, centerLng, _, _ := DecodeInt(center, bitDepth)
+
+	// the distance is at least the north/south distance
+	if math.Abs(centerLat-lat)*metersPerDegree > radiusMeters*(1+1e-9) {
+		return false
+	}
+
+	halfAngle := radiusMeters / (2 * EarthRadiusMeters)
+	if halfAngle >= math.Pi/2 {
+		return true
+	}
+	threshold := math.Sin(halfAngle) * math.Sin(halfAngle)
+
+	a := haversine(centerLat, centerLng, lat, lng)
+	if math.Abs(a-threshold) > threshold*1e-9 {
+		return a < threshold
+	}
+
+	// too close to call, so compare exactly as DistanceInt would
+	return Distance(centerLat, centerLng, lat, lng) <= radiusMeters
+}
+
+// DestinationPoint will return the point reached by travelling distanceMeters along the great circle that starts from
+// the supplied point on the initial bearing (in degrees clockwise from north).
+//
+// A path may pass over a pole, continuing on the other side of the globe, and the longitude is wrapped into the range
+// -180 to 180 when it crosses the antimeridian.
+func DestinationPoint(lat float64, lng float64, bearingDegrees float64, distanceMeters float64) (float64, float64) {
+	latRad := lat * math.Pi / 180
+	bearing := bearingDegrees * math.Pi / 180
+	angle := distanceMeters / EarthRadiusMeters
+
+	sinLat := math.Sin(latRad)*math.Cos(angle) + math.Cos(latRad)*math.Sin(angle)*math.Cos(bearing)
+	destLat := math.Asin(math.Max(math.Min(sinLat, 1), -1))
+	deltaLng := math.Atan2(math.Sin(bearing)*math.Sin(angle)*math.Cos(latRad), math.Cos(angle)-math.Sin(latRad)*sinLat)
+	return destLat * 180 / math.Pi, normalizeLng(lng + deltaLng*180/math.Pi)
+}
+
+// distanceToSegment returns the distance in meters from a point to the great-circle segment from a to b.
+func distanceToSegment(point Point, a Point, b Point) float64 {
+	p := toVector(point.Lat, point.Lng)
+	va := toVector(a.Lat, a.Lng)
+	vb := toVector(b.Lat, b.Lng)
+
+	normal := cross(va, vb)
+	length := math.Sqrt(dot(normal, normal))
+	if length < 1e-15 {
+		// a degenerate segment is a point
+		return EarthRadiusMeters * angleBetween(p, va)
+	}
+	normal = vector{normal.x / length, normal.y / length, normal.z / length}
+
+	// the closest point on the great circle is only used when it lies between a and b
+	offset := dot(p, normal)
+	projected := vector{p.x - offset*normal.x, p.y - offset*normal.y, p.z - offset*normal.z}
+	if dot(cross(va, projected), normal) >= 0 && dot(cross(projected, vb), normal) >= 0 {
+		return EarthRadiusMeters * math.Asin(math.Min(math.Abs(offset), 1))
+	}
+	return EarthRadiusMeters * math.Min(angleBetween(p, va), angleBetween(p, vb))
+}
+
+// distanceToRect returns the distance in meters from a point to the nearest point of a lat/lng rectangle (that does
+// not cross the antimeridian), which is 0 when the point is inside it.
+func distanceToRect(point Point, minLat float64, minLng float64, maxLat float64, maxLng float64) float64 {
+	lat := math.Min(math.Max(point.Lat, minLat), maxLat)
+
+	// pick the nearer edge, going either way around the globe
+	lng := normalizeLng(point.Lng)
+	if lng < minLng || lng > maxLng {
+		if math.Abs(normalizeLng(lng-minLng)) < math.Abs(normalizeLng(lng-maxLng)) {
+			lng = minLng
+		} else {
+			lng = maxLng
+		}
+	}
+	return Distance(point.Lat, point.Lng, lat, lng)
+}
+
+// angleToRect returns the angle in radians from a point to the nearest point of a lat/lng rectangle (that does not
+// cross the antimeridian), which is 0 when the point is inside it.
+//
+// Unlike distanceToRect it is exact for any distance: the nearest point is either on a meridian edge, which is a
+// great-circle segment, or directly north or south of the point on a parallel edge.
+func angleToRect(point Point, minLat float64, minLng float64, maxLat float64, maxLng float64) float64 {
+	lng := normalizeLng(point.Lng)
+	withinLng := lng >= minLng && lng <= maxLng
+	if withinLng && point.Lat >= minLat && point.Lat <= maxLat {
+		return 0
+	}
+
+	angle := math.Pi
+	if withinLng {
+		angle = math.Min(math.Abs(point.Lat-minLat), math.Abs(point.Lat-maxLat)) * math.Pi / 180
+	}
+	for _, edgeLng := range []float64{minLng, maxLng} {
+		a := Point{Lat: minLat, Lng: edgeLng}
+		b := Point{Lat: maxLat, Lng: edgeLng}
+		if edge := distanceToSegment(point, a, b) / EarthRadiusMeters; edge < angle {
+			angle = edge
+		}
+	}
+	return angle
+}
+
+// haversine returns the haversine of the central angle between two points, sin²(angle/2).
+func haversine(lat1 float64, lng1 float64, lat2 float64, lng2 float64) float64 {
+	lat1Rad := lat1 * math.Pi / 180
+	lat2Rad := lat2 * math.Pi / 180
+	sinLat := math.Sin((lat2Rad - lat1Rad) / 2)
+	sinLng := math.Sin((lng2 - lng1) * math.Pi / 180 / 2)
+	return math.Min(sinLat*sinLat+math.Cos(lat1Rad)*math.Cos(lat2Rad)*sinLng*sinLng, 1)
+}
@@ -0,0 +1,128 @@
+package geohash
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	// Sydney to Melbourne
+	result := Distance(-33.8688, 151.2093, -37.8136, 144.9631)
+	if math.Abs(result-713800) > 1000 {
+		t.Errorf("Expected about %+v but was %+v", 713800, result)
+	}
+
+	if result := Distance(10, 20, 10, 20); result != 0 {
+		t.Errorf("Expected %+v but was %+v", 0, result)
+	}
+}
+
+func TestDistanceInt(t *testing.T) {
+	geohash := EncodeInt(37.8324, 112.5584, MaxBitDepth)
+	if result := DistanceInt(geohash, 37.8324, 112.5584, MaxBitDepth); result > 1 {
+		t.Errorf("Expected less than a meter but was %+v", result)
+	}
+}
+
+func TestWithinRadiusInt(t *testing.T) {
+	center := EncodeInt(37.8324, 112.5584, 40)
+
+	for i := 0; i < 1000; i++ { // 1000 tests
+		lat := 37.8324 + rand.Float64()*0.2 - 0.1
+		lng := 112.5584 + rand.Float64()*0.2 - 0.1
+		distance := DistanceInt(center, lat, lng, 40)
+
+		// exactly on, just inside and just outside the radius as well as a random one
+		for _, radius := range []float64{distance, math.Nextafter(distance, 0), math.Nextafter(distance, math.Inf(1)), rand.Float64() * 20000} {
+			expected := distance <= radius
+			if result := WithinRadiusInt(center, lat, lng, radius, 40); expected != result {
+				t.Errorf("Expected %+v but was %+v for %+v,%+v within %+v", expected, result, lat, lng, radius)
+			}
+		}
+	}
+
+	if !WithinRadiusInt(center, -37.8324, -67.4416, 30000000, 40) {
+		t.Errorf("Expected every point to be within half the circumference")
+	}
+}
+
+func TestDestinationPoint(t *testing.T) {
+	scenarios := []struct {
+		desc        string
+		lat         float64
+		lng         float64
+		bearing     float64
+		distance    float64
+		expectedLat float64
+		expectedLng float64
+	}{
+		{desc: "none", lat: 37.8324, lng: 112.5584, bearing: 45, distance: 0, expectedLat: 37.8324, expectedLng: 112.5584},
+		{desc: "north", lat: 0, lng: 10, bearing: 0, distance: metersPerDegree * 10, expectedLat: 10, expectedLng: 10},
+		{desc: "east along the equator", lat: 0, lng: 10, bearing: 90, distance: metersPerDegree * 10, expectedLat: 0, expectedLng: 20},
+		{desc: "antimeridian", lat: 0, lng: 175, bearing: 90, distance: metersPerDegree * 10, expectedLat: 0, expectedLng: -175},
+		{desc: "over the north pole", lat: 85, lng: 10, bearing: 0, distance: metersPerDegree * 10, expectedLat: 85, expectedLng: -170},
+		{desc: "over the south pole", lat: -85, lng: -10, bearing: 180, distance: metersPerDegree * 10, expectedLat: -85, expectedLng: 170},
+	}
+
+	for _, scenario := range scenarios {
+		lat, lng := DestinationPoint(scenario.lat, scenario.lng, scenario.bearing, scenario.distance)
+		if !ApproxEqual(scenario.expectedLat, lat, 1e-9) || !ApproxEqual(scenario.expectedLng, lng, 1e-9) {
+			t.Errorf("%s: Expected %+v,%+v but was %+v,%+v", scenario.desc, scenario.expectedLat, scenario.expectedLng, lat, lng)
+		}
+	}
+}
+
+func TestDestinationPointDistance(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		lat := random.Float64()*170 - 85
+		lng := random.Float64()*360 - 180
+		distance := random.Float64() * 1000000
+
+		destLat, destLng := DestinationPoint(lat, lng, random.Float64()*360, distance)
+		if result := Distance(lat, lng, destLat, destLng); math.Abs(distance-result) > 1e-6 {
+			t.Errorf("Expected %+v but was %+v", distance, result)
+		}
+	}
+}
+
+func TestDistanceToSegment(t *testing.T) {
+	a := Point{0, 0}
+	b := Point{0, 10}
+
+	// beside the middle of the segment
+	expected := Distance(1, 5, 0, 5)
+	if result := distanceToSegment(Point{1, 5}, a, b); math.Abs(expected-result) > 0.001 {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+
+	// beyond the end of the segment
+	expected = Distance(0, 12, 0, 10)
+	if result := distanceToSegment(Point{0, 12}, a, b); math.Abs(expected-result) > 0.001 {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+
+	// degenerate segment
+	expected = Distance(1, 1, 0, 0)
+	if result := distanceToSegment(Point{1, 1}, a, a); math.Abs(expected-result) > 0.001 {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
+
+func TestDistanceToRect(t *testing.T) {
+	if result := distanceToRect(Point{5, 5}, 0, 0, 10, 10); result != 0 {
+		t.Errorf("Expected %+v but was %+v", 0, result)
+	}
+
+	expected := Distance(5, 12, 5, 10)
+	if result := distanceToRect(Point{5, 12}, 0, 0, 10, 10); math.Abs(expected-result) > 0.001 {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+
+	// across the antimeridian
+	expected = Distance(5, -179, 5, 179)
+	if result := distanceToRect(Point{5, -179}, 0, 170, 10, 179); math.Abs(expected-result) > 0.001 {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
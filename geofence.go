@@ -0,0 +1,70 @@
+package geohash
+
+// Geofence is a region described by a covering: the set of geohash cells at a fixed bit depth that it intersects.
+//
+// Testing whether a point is inside the geofence is a single map lookup of the point's cell, so it is suited to
+// evaluating a stream of location updates for enter and exit events. As the covering includes every cell the region
+// touches, points near the boundary may be reported as inside when they are just outside.
+//
+// A Geofence is immutable once created and so is safe for concurrent use by multiple goroutines.
+type Geofence struct {
+	bitDepth int64
+	cells    map[int64]bool
+}
+
+// NewGeofence will create a Geofence from an existing covering of geohash integers at bitDepth.
+func NewGeofence(hashes []int64, bitDepth int64) *Geofence {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	return &Geofence{
+		bitDepth: bitDepth,
+		cells:    toSet(hashes),
+	}
+}
+
+// NewGeofenceFromPolygon will create a Geofence covering the polygon with cells of bitDepth, see CoverPolygonInt.
+func NewGeofenceFromPolygon(polygon []Point, bitDepth int64) *Geofence {
+	return NewGeofence(CoverPolygonInt(polygon, bitDepth), bitDepth)
+}
+
+// NewGeofenceFromCircle will create a Geofence covering the circle of radiusMeters around the supplied point with
+// cells of bitDepth, see CoverRadiusInt.
+func NewGeofenceFromCircle(lat float64, lng float64, radiusMeters float64, bitDepth int64) *Geofence {
+	return NewGeofence(CoverRadiusInt(lat, lng, radiusMeters, bitDepth), bitDepth)
+}
+
+// BitDepth returns the bit depth of the geofence's cells.
+func (g *Geofence) BitDepth() int64 {
+	return g.bitDepth
+}
+
+// Len returns the number of cells in the geofence.
+func (g *Geofence) Len() int {
+	return len(g.cells)
+}
+
+// Hashes returns the geohash integers of the geofence's cells, sorted ascending.
+func (g *Geofence) Hashes() []int64 {
+	output := make([]int64, 0, len(g.cells))
+	for geohash := range g.cells {
+		output = append(output, geohash)
+	}
+
+	sortHashes(output)
+	return output
+}
+
+// Contains returns true when the supplied point falls in one of the geofence's cells.
+func (g *Geofence) Contains(lat float64, lng float64) bool {
+	return g.cells[EncodeInt(lat, lng, g.bitDepth)]
+}
+
+// AreaSqMeters returns the total surface area of the geofence's cells in square meters, see CellAreaSqMeters.
+func (g *Geofence) AreaSqMeters() float64 {
+	var total float64
+	for geohash := range g.cells {
+		total += CellAreaSqMeters(geohash, g.bitDepth)
+	}
+	return total
+}
@@ -0,0 +1,75 @@
+package geohash
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGeofenceFromPolygon(t *testing.T) {
+	polygon := []Point{
+		{Lat: -37.80, Lng: 144.95},
+		{Lat: -37.80, Lng: 145.00},
+		{Lat: -37.85, Lng: 145.00},
+		{Lat: -37.85, Lng: 144.95},
+	}
+	geofence := NewGeofenceFromPolygon(polygon, 30)
+
+	if geofence.BitDepth() != 30 {
+		t.Errorf("Expected %+v but was %+v", 30, geofence.BitDepth())
+	}
+	if geofence.Len() == 0 || geofence.Len() != len(geofence.Hashes()) {
+		t.Errorf("Expected %+v cells but was %+v", len(geofence.Hashes()), geofence.Len())
+	}
+
+	scenarios := []struct {
+		desc     string
+		lat      float64
+		lng      float64
+		expected bool
+	}{
+		{desc: "inside", lat: -37.82, lng: 144.97, expected: true},
+		{desc: "corner", lat: -37.8001, lng: 144.9501, expected: true},
+		{desc: "outside", lat: -37.90, lng: 144.97, expected: false},
+		{desc: "far away", lat: 37.82, lng: -144.97, expected: false},
+	}
+
+	for _, scenario := range scenarios {
+		result := geofence.Contains(scenario.lat, scenario.lng)
+		if scenario.expected != result {
+			t.Errorf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected, result)
+		}
+	}
+}
+
+func TestGeofenceFromCircle(t *testing.T) {
+	geofence := NewGeofenceFromCircle(51.5007, -0.1246, 500, 36)
+
+	if !geofence.Contains(51.5007, -0.1246) {
+		t.Errorf("Expected the center to be inside")
+	}
+	if !geofence.Contains(51.5040, -0.1246) {
+		t.Errorf("Expected a point 370m north to be inside")
+	}
+	if geofence.Contains(51.5100, -0.1246) {
+		t.Errorf("Expected a point 1km north to be outside")
+	}
+
+	// the covering is at least the area of the circle but not too much more
+	circle := math.Pi * 500 * 500
+	if area := geofence.AreaSqMeters(); area < circle || area > circle*2 {
+		t.Errorf("Expected an area near %+v but was %+v", circle, area)
+	}
+}
+
+func TestGeofenceAreaSqMeters(t *testing.T) {
+	hashes := []int64{EncodeInt(0.1, 0.1, 20), EncodeInt(10.1, 10.1, 20)}
+	geofence := NewGeofence(append(hashes, hashes[0]), 20)
+
+	expected := CellAreaSqMeters(hashes[0], 20) + CellAreaSqMeters(hashes[1], 20)
+	if result := geofence.AreaSqMeters(); math.Abs(expected-result) > 1e-6 {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+	if geofence.Len() != 2 {
+		t.Errorf("Expected %+v but was %+v", 2, geofence.Len())
+	}
+}
@@ -0,0 +1,17 @@
+package geohash
+
+// EncodeIntF32 is the same as EncodeInt for float32 coordinates.
+//
+// Note: float32 only holds about 7 significant digits, which is under a meter of longitude near the antimeridian, so at
+// bit depths above about 48 the precision of the input, rather than the geohash, limits the accuracy.
+func EncodeIntF32(latitude float32, longitude float32, bitDepth int64) int64 {
+	return EncodeInt(float64(latitude), float64(longitude), bitDepth)
+}
+
+// DecodeIntF32 is the same as DecodeInt but returns float32 values.
+//
+// The values are calculated as float64 and then rounded to the nearest float32, see EncodeIntF32 for the precision caveat.
+func DecodeIntF32(geohash int64, bitDepth int64) (lat float32, lng float32, latErr float32, lngErr float32) {
+	lat64, lng64, latErr64, lngErr64 := DecodeInt(geohash, bitDepth)
+	return float32(lat64), float32(lng64), float32(latErr64), float32(lngErr64)
+}
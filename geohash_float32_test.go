@@ -0,0 +1,29 @@
+package geohash
+
+import (
+	"testing"
+)
+
+func TestEncodeIntF32(t *testing.T) {
+	var lat float32 = 37.8324
+	var lng float32 = 112.5584
+
+	expected := EncodeInt(float64(lat), float64(lng), 40)
+	result := EncodeIntF32(lat, lng, 40)
+	if expected != result {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
+
+func TestDecodeIntF32(t *testing.T) {
+	var expectedLat float32 = 37.8324
+	var expectedLng float32 = 112.5584
+
+	resultLat, resultLng, latErr, lngErr := DecodeIntF32(EncodeIntF32(expectedLat, expectedLng, 40), 40)
+	if resultLat-expectedLat > latErr || expectedLat-resultLat > latErr {
+		t.Errorf("Expected %+v but was %+v", expectedLat, resultLat)
+	}
+	if resultLng-expectedLng > lngErr || expectedLng-resultLng > lngErr {
+		t.Errorf("Expected %+v but was %+v", expectedLng, resultLng)
+	}
+}
@@ -0,0 +1,91 @@
+package geohash
+
+const (
+	// MaxBitDepth32 defines the maximum geohash accuracy that can be stored in an int32.
+	MaxBitDepth32 int64 = 30
+)
+
+// Integer is the set of integer types a geohash can be stored in.
+type Integer interface {
+	~int32 | ~int64
+}
+
+// Encode will encode a pair of latitude and longitude values into a geohash of integer type T.
+//
+// This is identical to EncodeInt but allows the result to be stored in a smaller type without a lossy cast.
+// The bitDepth must be even and no more than MaxBitDepth64 for int64 or MaxBitDepth32 for int32.
+//
+// A coordinate that lies exactly on a cell boundary belongs to the cell to the south or west of it, as each bit is
+// only set when the coordinate is strictly greater than the midpoint. The exceptions are latitude 90 and longitude 180
+// which belong to the northernmost and easternmost cells. The midpoints are exact in float64 (they are only ever
+// halved and summed), so a given float64 coordinate encodes to the same cell on every platform.
+func Encode[T Integer](latitude float64, longitude float64, bitDepth int64) T {
+	// input validation
+	validateBitDepthMax(bitDepth, maxBitDepthOf[T]())
+	validateCoordinates(latitude, longitude)
+
+	// initialize the calculation
+	var bitsTotal int64
+	var mid float64
+	var maxLat float64 = 90.0
+	var minLat float64 = -90.0
+	var maxLng float64 = 180.0
+	var minLng float64 = -180.0
+
+	var geohash T
+	for bitsTotal < bitDepth {
+		geohash *= 2
+
+		if bitsTotal%2 == 0 {
+			mid = (maxLng + minLng) / 2
+
+			// strictly greater so that boundaries belong to the lower cell, see above
+			if longitude > mid {
+				geohash += 1
+				minLng = mid
+			} else {
+				maxLng = mid
+			}
+		} else {
+			mid = (maxLat + minLat) / 2
+			if latitude > mid {
+				geohash += 1
+				minLat = mid
+			} else {
+				maxLat = mid
+			}
+		}
+		bitsTotal++
+	}
+	return geohash
+}
+
+// Decode is the generic version of DecodeInt and will decode a geohash of integer type T into latitude and
+// longitude value approximations along with the maximum error of the calculation.
+func Decode[T Integer](geohash T, bitDepth int64) (lat float64, lng float64, latErr float64, lngErr float64) {
+	// input validation
+	validateBitDepthMax(bitDepth, maxBitDepthOf[T]())
+
+	return DecodeInt(int64(geohash), bitDepth)
+}
+
+// EncodeInt32 will encode a pair of latitude and longitude values into an int32 geohash.
+//
+// The bitDepth must be even and no more than MaxBitDepth32.
+func EncodeInt32(latitude float64, longitude float64, bitDepth int64) int32 {
+	return Encode[int32](latitude, longitude, bitDepth)
+}
+
+// DecodeInt32 will decode an int32 geohash into a pair of latitude and longitude value approximations.
+func DecodeInt32(geohash int32, bitDepth int64) (lat float64, lng float64, latErr float64, lngErr float64) {
+	return Decode(geohash, bitDepth)
+}
+
+// maxBitDepthOf returns the maximum even bit depth that fits in the non-negative range of T.
+func maxBitDepthOf[T Integer]() int64 {
+	// bit 31 is the sign bit of a 32 bit T, so shifting into it overflows to a negative value
+	if T(1)<<31 < 0 {
+		return MaxBitDepth32
+	}
+	return MaxBitDepth64
+}
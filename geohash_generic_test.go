@@ -0,0 +1,64 @@
+package geohash
+
+import (
+	"testing"
+)
+
+func TestEncodeMatchesEncodeInt(t *testing.T) {
+	for _, bitDepth := range []int64{2, 16, 30} {
+		expected := EncodeInt(37.8324, 112.5584, bitDepth)
+
+		result32 := Encode[int32](37.8324, 112.5584, bitDepth)
+		if expected != int64(result32) {
+			t.Errorf("Expected %+v but was %+v", expected, result32)
+		}
+
+		result64 := Encode[int64](37.8324, 112.5584, bitDepth)
+		if expected != result64 {
+			t.Errorf("Expected %+v but was %+v", expected, result64)
+		}
+	}
+}
+
+func TestEncodeInt32RoundTrip(t *testing.T) {
+	geohash := EncodeInt32(-33.8688, 151.2093, MaxBitDepth32)
+	if geohash < 0 {
+		t.Errorf("Expected a positive geohash but was %+v", geohash)
+	}
+
+	lat, lng, latErr, lngErr := DecodeInt32(geohash, MaxBitDepth32)
+	if !ApproxEqual(-33.8688, lat, latErr) || !ApproxEqual(151.2093, lng, lngErr) {
+		t.Errorf("Expected %+v,%+v but was %+v,%+v", -33.8688, 151.2093, lat, lng)
+	}
+}
+
+type customHash int32
+
+func TestEncodeCustomType(t *testing.T) {
+	var expected customHash = customHash(EncodeInt32(37.8324, 112.5584, 20))
+	result := Encode[customHash](37.8324, 112.5584, 20)
+	if expected != result {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
+
+func TestEncodeInt32BitDepthLimit(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected panic() for a bit depth above MaxBitDepth32")
+		}
+	}()
+	EncodeInt32(37.8324, 112.5584, 32)
+}
+
+func TestMaxBitDepthOf(t *testing.T) {
+	if result := maxBitDepthOf[int32](); result != MaxBitDepth32 {
+		t.Errorf("Expected %+v but was %+v", MaxBitDepth32, result)
+	}
+	if result := maxBitDepthOf[customHash](); result != MaxBitDepth32 {
+		t.Errorf("Expected %+v but was %+v", MaxBitDepth32, result)
+	}
+	if result := maxBitDepthOf[int64](); result != MaxBitDepth64 {
+		t.Errorf("Expected %+v but was %+v", MaxBitDepth64, result)
+	}
+}
@@ -6,11 +6,15 @@ import (
 )
 
 const (
-	// MaxBitDepth defines both the maximum and default geohash accuracy.
+	// MaxBitDepth defines the default geohash accuracy and the reference accuracy used by Shift.
 	MaxBitDepth int64 = 52
+
+	// MaxBitDepth64 defines the maximum geohash accuracy, which is the most that can be stored in an int64.
+	// Cells are roughly 2cm at this depth.
+	MaxBitDepth64 int64 = 62
 )
 
-// bearing defines the compass bearing/direction in matrix form relative to a center point of 0,0
+// Bearing defines the compass bearing/direction in matrix form relative to a center point of 0,0
 //  |----------------------|
 // 	|   NW  |   N   |  NE  |
 // 	|  1,-1 |  1,0  |  1,1 |
@@ -18,110 +22,101 @@ const (
 // 	|   W   |   X   |   E  |
 // 	|  0,-1 |  0,0  |  0,1 |
 //  |----------------------|
-// 	|   SW  |   S   |  NE  |
+// 	|   SW  |   S   |  SE  |
 // 	| -1,-1 |  -1,0 | -1,1 |
 //  |----------------------|
-type bearing struct {
+type Bearing struct {
 	x, y int
 }
 
 // North bearing from reference point X
-var North = bearing{1, 0}
+var North = Bearing{1, 0}
 
 // NorthEast bearing from reference point X
-var NorthEast = bearing{1, 1}
+var NorthEast = Bearing{1, 1}
 
 // East bearing from reference point X
-var East = bearing{0, 1}
+var East = Bearing{0, 1}
 
 // SouthEast bearing from reference point X
-var SouthEast = bearing{-1, 1}
+var SouthEast = Bearing{-1, 1}
 
 // South bearing from reference point X
-var South = bearing{-1, 0}
+var South = Bearing{-1, 0}
 
 // SouthWest bearing from reference point X
-var SouthWest = bearing{-1, -1}
+var SouthWest = Bearing{-1, -1}
 
 // West bearing from reference point X
-var West = bearing{0, -1}
+var West = Bearing{0, -1}
 
 // NorthWest bearing from reference point X
-var NorthWest = bearing{1, -1}
+var NorthWest = Bearing{1, -1}
+
+// Center is the reference point X itself
+var Center = Bearing{0, 0}
 
-// bitsToDistanceInMeters provides a mapping between bitDepth values and distances
+// bitsToDistanceInMeters provides a mapping between bitDepth values and distances, starting at MaxBitDepth64
 var bitsToDistanceInMeters []float64
 
 func init() {
-	bitsToDistanceInMeters = make([]float64, 25)
-	bitsToDistanceInMeters[0] = 0.5971
-	bitsToDistanceInMeters[1] = 1.1943
-	bitsToDistanceInMeters[2] = 2.3889
-	bitsToDistanceInMeters[3] = 4.7774
-	bitsToDistanceInMeters[4] = 9.5547
-	bitsToDistanceInMeters[5] = 19.1095
-	bitsToDistanceInMeters[6] = 38.2189
-	bitsToDistanceInMeters[7] = 76.4378
-	bitsToDistanceInMeters[8] = 152.8757
-	bitsToDistanceInMeters[9] = 305.751
-	bitsToDistanceInMeters[10] = 611.5028
-	bitsToDistanceInMeters[11] = 1223.0056
-	bitsToDistanceInMeters[12] = 2446.0112
-	bitsToDistanceInMeters[13] = 4892.0224
-	bitsToDistanceInMeters[14] = 9784.0449
-	bitsToDistanceInMeters[15] = 19568.0898
-	bitsToDistanceInMeters[16] = 39136.1797
-	bitsToDistanceInMeters[17] = 78272.35938
-	bitsToDistanceInMeters[18] = 156544.7188
-	bitsToDistanceInMeters[19] = 313089.4375
-	bitsToDistanceInMeters[20] = 626178.875
-	bitsToDistanceInMeters[21] = 1252357.75
-	bitsToDistanceInMeters[22] = 2504715.5
-	bitsToDistanceInMeters[23] = 5009431
-	bitsToDistanceInMeters[24] = 10018863
+	bitsToDistanceInMeters = make([]float64, 30)
+	bitsToDistanceInMeters[0] = 0.0187
+	bitsToDistanceInMeters[1] = 0.0373
+	bitsToDistanceInMeters[2] = 0.0746
+	bitsToDistanceInMeters[3] = 0.1493
+	bitsToDistanceInMeters[4] = 0.2986
+	bitsToDistanceInMeters[5] = 0.5971
+	bitsToDistanceInMeters[6] = 1.1943
+	bitsToDistanceInMeters[7] = 2.3889
+	bitsToDistanceInMeters[8] = 4.7774
+	bitsToDistanceInMeters[9] = 9.5547
+	bitsToDistanceInMeters[10] = 19.1095
+	bitsToDistanceInMeters[11] = 38.2189
+	bitsToDistanceInMeters[12] = 76.4378
+	bitsToDistanceInMeters[13] = 152.8757
+	bitsToDistanceInMeters[14] = 305.751
+	bitsToDistanceInMeters[15] = 611.5028
+	bitsToDistanceInMeters[16] = 1223.0056
+	bitsToDistanceInMeters[17] = 2446.0112
+	bitsToDistanceInMeters[18] = 4892.0224
+	bitsToDistanceInMeters[19] = 9784.0449
+	bitsToDistanceInMeters[20] = 19568.0898
+	bitsToDistanceInMeters[21] = 39136.1797
+	bitsToDistanceInMeters[22] = 78272.35938
+	bitsToDistanceInMeters[23] = 156544.7188
+	bitsToDistanceInMeters[24] = 313089.4375
+	bitsToDistanceInMeters[25] = 626178.875
+	bitsToDistanceInMeters[26] = 1252357.75
+	bitsToDistanceInMeters[27] = 2504715.5
+	bitsToDistanceInMeters[28] = 5009431
+	bitsToDistanceInMeters[29] = 10018863
 }
 
 // EncodeInt will encode a pair of latitude and longitude values into a geohash integer.
 //
 // The third argument is the bitDepth of this number, which affects the precision of the geohash
 // but also must be used consistently when decoding. Bit depth must be even.
+//
+// Coordinates on a cell boundary belong to the cell to the south or west of it, see Encode.
+//
+// A NaN or infinite latitude or longitude will cause panic(), see EncodeIntE for an alternative.
 func EncodeInt(latitude float64, longitude float64, bitDepth int64) int64 {
-	// input validation
-	validateBitDepth(bitDepth)
-
-	// initialize the calculation
-	var bitsTotal int64
-	var mid float64
-	var maxLat float64 = 90.0
-	var minLat float64 = -90.0
-	var maxLng float64 = 180.0
-	var minLng float64 = -180.0
-
-	var geohash int64
-	for bitsTotal < bitDepth {
-		geohash *= 2
-
-		if bitsTotal%2 == 0 {
-			mid = (maxLng + minLng) / 2
+	return Encode[int64](latitude, longitude, bitDepth)
+}
 
-			if longitude > mid {
-				geohash += 1
-				minLng = mid
-			} else {
-				maxLng = mid
-			}
-		} else {
-			mid = (maxLat + minLat) / 2
-			if latitude > mid {
-				geohash += 1
-				minLat = mid
-			} else {
-				maxLat = mid
-			}
-		}
-		bitsTotal++
+// EncodeIntE is the same as EncodeInt but returns an error instead of causing panic() when the bitDepth is invalid
+// or the latitude or longitude is NaN or infinite.
+func EncodeIntE(latitude float64, longitude float64, bitDepth int64) (int64, error) {
+	// input validation
+	if err := checkBitDepth(bitDepth, MaxBitDepth64); err != nil {
+		return 0, err
 	}
-	return geohash
+	if err := checkCoordinates(latitude, longitude); err != nil {
+		return 0, err
+	}
+
+	return EncodeInt(latitude, longitude, bitDepth), nil
 }
 
 // DecodeInt with decode a integer geohashed number into pair of latitude and longitude value approximations.
@@ -143,6 +138,74 @@ func DecodeInt(geohash int64, bitDepth int64) (lat float64, lng float64, latErr
 	return
 }
 
+// DecodedCell holds the result of decoding a geohash integer, see DecodeIntInto.
+type DecodedCell struct {
+	Lat    float64
+	Lng    float64
+	LatErr float64
+	LngErr float64
+}
+
+// DecodeIntInto is the same as DecodeInt but writes the result into out, so that one DecodedCell can be reused
+// across many calls.
+//
+// Rather than bisecting one bit at a time it splits the cell indexes out of geohash (see DecodeMorton2D) and scales
+// them directly, which gives the same result around three times faster (see BenchmarkDecodeIntInto).
+func DecodeIntInto(geohash int64, bitDepth int64, out *DecodedCell) {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	x, y := DecodeMorton2D(uint64(geohash) & (uint64(1)<<uint64(bitDepth) - 1))
+	cells := float64(int64(1) << uint64(bitDepth/2))
+	latSize := 180 / cells
+	lngSize := 360 / cells
+
+	minLat := float64(x)*latSize - 90
+	minLng := float64(y)*lngSize - 180
+	maxLat := minLat + latSize
+	maxLng := minLng + lngSize
+
+	out.Lat = (minLat + maxLat) / 2
+	out.Lng = (minLng + maxLng) / 2
+	out.LatErr = maxLat - out.Lat
+	out.LngErr = maxLng - out.Lng
+}
+
+// DecodeIntRounded will decode an integer geohash into the latitude and longitude of its center, rounded to the
+// requested number of decimal places.
+//
+// This gives stable, presentation ready coordinates but note that rounding to more decimal places than the
+// bitDepth supports does not make the result more accurate.
+func DecodeIntRounded(geohash int64, bitDepth int64, decimals int) (lat float64, lng float64) {
+	lat, lng, _, _ = DecodeInt(geohash, bitDepth)
+	return Round(lat, decimals), Round(lng, decimals)
+}
+
+// DecodeIntCorner will decode an integer geohash into the latitude and longitude of the south west corner of its
+// cell, rather than the center returned by DecodeInt.
+//
+// This is the (minLat, minLng) of DecodeBboxInt, the corner used to key cells by some tiling schemes. Note that points
+// on a boundary encode into the cell to the south or west of it (see EncodeInt), so EncodeInt of the corner does not
+// give back geohash except along the southern and western edges of the globe.
+func DecodeIntCorner(geohash int64, bitDepth int64) (lat float64, lng float64) {
+	lat, lng, _, _ = DecodeBboxInt(geohash, bitDepth)
+	return
+}
+
+// DisplayDecimals will return the number of decimal places that are meaningful when displaying the coordinates of a
+// cell of bitDepth, for use with DecodeIntRounded.
+//
+// This is the first decimal place that is smaller than the cell's latitude error (half its height), so the last
+// digit shown is the first that varies within the cell. Longitude uses the same number of places even though its
+// error is twice as large. Very coarse cells return 0.
+func DisplayDecimals(bitDepth int64) int {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	latErr := 90 / math.Exp2(float64(bitDepth/2))
+	return int(math.Max(math.Ceil(-math.Log10(latErr)), 0))
+}
+
 // DecodeBboxInt will decode a geohash integer into the bounding box that matches it.
 //
 // Returned as a four corners of a square region.
@@ -183,7 +246,7 @@ func DecodeBboxInt(geohash int64, bitDepth int64) (minLat float64, minLng float6
 // NeighborInt will find the neighbor of a integer geohash in certain bearing/direction.
 //
 // The bitDepth should be specified and the same as the value used to generate the hash.
-func NeighborInt(geohash int64, bearing bearing, bitDepth int64) int64 {
+func NeighborInt(geohash int64, bearing Bearing, bitDepth int64) int64 {
 	// input validation
 	validateBitDepth(bitDepth)
 
@@ -194,51 +257,379 @@ func NeighborInt(geohash int64, bearing bearing, bitDepth int64) int64 {
 }
 
 // NeighborsInt is the same as calling NeighborInt for each direction and will return all 8 neighbors and the center location.
+//
+// The neighbors are in clockwise order from North and the center is always the last (9th) element, see SurroundingInt
+// for only the 8 neighbors.
 func NeighborsInt(geohash int64, bitDepth int64) []int64 {
 	// input validation
 	validateBitDepth(bitDepth)
 
-	var output []int64
-	output = append(output, NeighborInt(geohash, North, bitDepth))
-	output = append(output, NeighborInt(geohash, NorthEast, bitDepth))
-	output = append(output, NeighborInt(geohash, East, bitDepth))
-	output = append(output, NeighborInt(geohash, SouthEast, bitDepth))
-	output = append(output, NeighborInt(geohash, South, bitDepth))
-	output = append(output, NeighborInt(geohash, SouthWest, bitDepth))
-	output = append(output, NeighborInt(geohash, West, bitDepth))
-	output = append(output, NeighborInt(geohash, NorthWest, bitDepth))
+	return NeighborsIntInto(geohash, bitDepth, make([]int64, 0, 9))
+}
+
+// SurroundingInt is the same as NeighborsInt without the center, returning only the 8 neighbors in clockwise order
+// from North.
+//
+// As with NeighborInt the neighbors beyond a pole stop at the polar row, so there they may include the center cell
+// itself.
+func SurroundingInt(geohash int64, bitDepth int64) []int64 {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	return NeighborsIntInto(geohash, bitDepth, make([]int64, 0, 9))[:8]
+}
+
+// EdgeNeighborsInt will return the 4 cells that share an edge with geohash, in the order North, East, South, West,
+// which are the neighbors of 4-connectivity (see IsContiguous).
+//
+// Longitude wraps around the antimeridian. As with StepInt the neighbor beyond a pole stops at the polar row, so there
+// the North or South neighbor is the cell itself.
+func EdgeNeighborsInt(geohash int64, bitDepth int64) []int64 {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	return []int64{
+		StepInt(geohash, 1, 0, bitDepth),
+		StepInt(geohash, 0, 1, bitDepth),
+		StepInt(geohash, -1, 0, bitDepth),
+		StepInt(geohash, 0, -1, bitDepth),
+	}
+}
+
+// NeighborsIntInto is the same as NeighborsInt but writes the results into out, which is truncated first, and returns
+// it so that a buffer can be reused across calls.
+//
+// No memory is allocated when out has a capacity of at least 9. The neighbors are found from the cell's latitude and
+// longitude indexes rather than by decoding and encoding each one, stopping at the edges of the grid as NeighborInt
+// does.
+func NeighborsIntInto(geohash int64, bitDepth int64, out []int64) []int64 {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	latIdx, lngIdx := deinterleave(geohash, bitDepth)
+	cells := int64(1) << uint64(bitDepth/2)
+	southIdx, northIdx := latIdx, latIdx
+	if latIdx > 0 {
+		southIdx--
+	}
+	if latIdx < cells-1 {
+		northIdx++
+	}
+	westIdx, eastIdx := lngIdx, lngIdx
+	if lngIdx > 0 {
+		westIdx--
+	}
+	if lngIdx < cells-1 {
+		eastIdx++
+	}
+
+	// the latitude and longitude bits are interleaved separately and combined for each neighbor
+	north, south := interleave(northIdx, 0, bitDepth), interleave(southIdx, 0, bitDepth)
+	east, west := interleave(0, eastIdx, bitDepth), interleave(0, westIdx, bitDepth)
+	lat, lng := interleave(latIdx, 0, bitDepth), interleave(0, lngIdx, bitDepth)
+
+	output := out[:0]
+	output = append(output, north|lng, north|east, lat|east, south|east, south|lng, south|west, lat|west, north|west)
 	output = append(output, geohash)
 	return output
 }
 
-// BboxesInt will return all the hash integers between minLat, minLon, maxLat, maxLon at the requested bitDepth
-func BboxesInt(minLat float64, minLon float64, maxLat float64, maxLon float64, bitDepth int64) []int64 {
+// NeighborhoodsInt will return the square neighborhood of cells around each of the supplied centers.
+//
+// A ringSize of 1 returns the 3x3 neighborhood, 2 returns 5x5 and so on. The cells are found with StepInt, so unlike
+// NeighborsInt longitude wraps around the antimeridian, as it does for EdgeNeighborsInt and AreAdjacentInt.
+// Neighborhoods are returned in the same order as centers, with each one in row-major order from its south west
+// cell and without duplicates (which occur near the poles).
+func NeighborhoodsInt(centers []int64, ringSize int, bitDepth int64) [][]int64 {
 	// input validation
 	validateBitDepth(bitDepth)
+	if ringSize < 0 {
+		panic(fmt.Sprintf("ringSize must not be negative, was %d", ringSize))
+	}
 
-	// find the corners
-	hashSouthWest := EncodeInt(minLat, minLon, bitDepth)
-	hashNorthEast := EncodeInt(maxLat, maxLon, bitDepth)
+	width := ringSize*2 + 1
+	output := make([][]int64, 0, len(centers))
+	for _, center := range centers {
+		neighborhood := make([]int64, 0, width*width)
+		seen := make(map[int64]bool, width*width)
+		for lat := -ringSize; lat <= ringSize; lat++ {
+			for lng := -ringSize; lng <= ringSize; lng++ {
+				neighbor := StepInt(center, lat, lng, bitDepth)
+				if !seen[neighbor] {
+					seen[neighbor] = true
+					neighborhood = append(neighborhood, neighbor)
+				}
+			}
+		}
+		output = append(output, neighborhood)
+	}
+	return output
+}
+
+// AreAdjacentInt will return true when b is one of the 8 neighbors (including diagonals) of a.
+//
+// A cell is not adjacent to itself. Cells either side of the antimeridian are adjacent.
+func AreAdjacentInt(a int64, b int64, bitDepth int64) bool {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	if a == b {
+		return false
+	}
+
+	aLat, aLng := deinterleave(a, bitDepth)
+	bLat, bLng := deinterleave(b, bitDepth)
+
+	cells := int64(1) << uint64(bitDepth/2)
+	deltaLat := aLat - bLat
+	deltaLng := (aLng - bLng + cells) % cells
+	return deltaLat >= -1 && deltaLat <= 1 && (deltaLng <= 1 || deltaLng == cells-1)
+}
+
+// GridDistanceInt will return how many cells east (dx) and north (dy) b is from a, negative for west and south.
+//
+// The longitude delta takes the shorter way around the globe, across the antimeridian if necessary. The Chebyshev
+// (king move) distance between the cells is the larger of |dx| and |dy|.
+func GridDistanceInt(a int64, b int64, bitDepth int64) (dx int, dy int) {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	aLat, aLng := deinterleave(a, bitDepth)
+	bLat, bLng := deinterleave(b, bitDepth)
+
+	cells := int64(1) << uint64(bitDepth/2)
+	deltaLng := (bLng - aLng + cells) % cells
+	if deltaLng > cells/2 {
+		deltaLng -= cells
+	}
+	return int(deltaLng), int(bLat - aLat)
+}
+
+// BitNeighborsInt will return the bitDepth geohash integers that differ from geohash by exactly one bit
+// (a Hamming distance of 1), ordered from the most to the least significant bit flipped.
+//
+// Note: these are neighbors in bit space, NOT spatial neighbors (see NeighborsInt). Flipping a high bit moves to the
+// other side of the globe while flipping the lowest bits moves to an adjacent cell.
+func BitNeighborsInt(geohash int64, bitDepth int64) []int64 {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	output := make([]int64, 0, bitDepth)
+	for position := bitDepth - 1; position >= 0; position-- {
+		output = append(output, geohash^(int64(1)<<uint64(position)))
+	}
+	return output
+}
+
+// StepInt will return the cell dLat rows north (negative for south) and dLng columns east (negative for west) of
+// geohash, a generalization of NeighborInt to any number of cells in both directions at once.
+//
+// Longitude wraps around the antimeridian. Latitude does not pass over the poles, so as with NeighborInt a step beyond
+// the northernmost or southernmost row stops at that row.
+func StepInt(geohash int64, dLat int, dLng int, bitDepth int64) int64 {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	latIdx, lngIdx := deinterleave(geohash, bitDepth)
+	cells := int64(1) << uint64(bitDepth/2)
+
+	latIdx += int64(dLat)
+	if latIdx < 0 {
+		latIdx = 0
+	} else if latIdx >= cells {
+		latIdx = cells - 1
+	}
+	lngIdx = ((lngIdx+int64(dLng))%cells + cells) % cells
+	return interleave(latIdx, lngIdx, bitDepth)
+}
+
+// OffsetInt will return the cell containing the center of geohash moved dLatDeg degrees north (negative for south) and
+// dLngDeg degrees east (negative for west).
+//
+// Longitude wraps around the antimeridian while latitude is clamped at the poles rather than passing over them. See
+// StepInt to move by a number of cells instead.
+func OffsetInt(geohash int64, dLatDeg float64, dLngDeg float64, bitDepth int64) int64 {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	lat, lng, _, _ := DecodeInt(geohash, bitDepth)
+	lat = math.Max(math.Min(lat+dLatDeg, 90), -90)
+	return EncodeInt(lat, normalizeLng(lng+dLngDeg), bitDepth)
+}
+
+// AntipodeInt will return the geohash integer of the cell diametrically opposite geohash on the globe, where the
+// latitude is negated and the longitude is 180 degrees away.
+//
+// The grid is symmetric about the equator and has an even number of columns, so the antipodal cell is exact and
+// AntipodeInt(AntipodeInt(geohash, bitDepth), bitDepth) is always geohash.
+func AntipodeInt(geohash int64, bitDepth int64) int64 {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	latIdx, lngIdx := deinterleave(geohash, bitDepth)
+	cells := int64(1) << uint64(bitDepth/2)
+	return interleave(cells-1-latIdx, (lngIdx+cells/2)%cells, bitDepth)
+}
+
+// stepCell returns the cell dLat rows north and dLng columns east of geohash, wrapping around the antimeridian.
+//
+// Returns false when the step would go beyond a pole.
+func stepCell(geohash int64, dLat int64, dLng int64, bitDepth int64) (int64, bool) {
+	latIdx, lngIdx := deinterleave(geohash, bitDepth)
+	cells := int64(1) << uint64(bitDepth/2)
+
+	latIdx += dLat
+	if latIdx < 0 || latIdx >= cells {
+		return 0, false
+	}
+	lngIdx = ((lngIdx+dLng)%cells + cells) % cells
+	return interleave(latIdx, lngIdx, bitDepth), true
+}
+
+// BearingBetweenInt will return the bearing of to relative to from when to is one of the 8 neighbors of from.
+//
+// This is the inverse of NeighborInt. Returns false when the cells are not adjacent, and Center and false when
+// from and to are the same cell.
+func BearingBetweenInt(from int64, to int64, bitDepth int64) (Bearing, bool) {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	if from == to {
+		return Center, false
+	}
+	if !AreAdjacentInt(from, to, bitDepth) {
+		return Bearing{}, false
+	}
+
+	fromLat, fromLng := deinterleave(from, bitDepth)
+	toLat, toLng := deinterleave(to, bitDepth)
 
-	_, _, latErr, lngErr := DecodeInt(hashSouthWest, bitDepth)
-	perLat := latErr * 2
-	perLng := lngErr * 2
+	cells := int64(1) << uint64(bitDepth/2)
+	deltaLng := (toLng - fromLng + cells) % cells
+	if deltaLng == cells-1 {
+		deltaLng = -1
+	}
+	return Bearing{int(toLat - fromLat), int(deltaLng)}, true
+}
 
-	swMinLat, _, _, swMaxLng := DecodeBboxInt(hashSouthWest, bitDepth)
-	neMinLat, _, _, neMaxLng := DecodeBboxInt(hashNorthEast, bitDepth)
+// CellTransitionInt will return the cells of a previous and a current position, whether the position changed cells
+// between them and, when it did, the general bearing of the move.
+//
+// The bearing is the direction of the current cell from the previous one (see GridDistanceInt), so it is the same as
+// BearingBetweenInt for adjacent cells and otherwise the nearest of the 8 directions with the longitude taking the
+// shorter way around the globe. Center is returned when the cell did not change.
+func CellTransitionInt(prevLat float64, prevLng float64, curLat float64, curLng float64, bitDepth int64) (from int64, to int64, changed bool, bearing Bearing) {
+	from = EncodeInt(prevLat, prevLng, bitDepth)
+	to = EncodeInt(curLat, curLng, bitDepth)
+	if from == to {
+		return from, to, false, Center
+	}
+
+	sign := func(value int) int {
+		if value < 0 {
+			return -1
+		} else if value > 0 {
+			return 1
+		}
+		return 0
+	}
+	dx, dy := GridDistanceInt(from, to, bitDepth)
+	return from, to, true, Bearing{sign(dy), sign(dx)}
+}
+
+// BboxesInt will return all the hash integers between minLat, minLon, maxLat, maxLon at the requested bitDepth
+//
+// Note: a bbox that crosses the antimeridian (see BboxCrossesAntimeridian) returns no hashes, it should be split
+// into the parts either side of it first.
+func BboxesInt(minLat float64, minLon float64, maxLat float64, maxLon float64, bitDepth int64) []int64 {
+	// input validation
+	validateBitDepth(bitDepth)
 
-	latStep := round((neMinLat-swMinLat)/perLat, 0.5, 0)
-	lngStep := round((neMaxLng-swMaxLng)/perLng, 0.5, 0)
+	hashSouthWest, latStep, lngStep := bboxSteps(minLat, minLon, maxLat, maxLon, bitDepth)
 
 	var output []int64
-	for lat := 0; lat <= int(latStep); lat++ {
-		for lng := 0; lng <= int(lngStep); lng++ {
-			output = append(output, NeighborInt(hashSouthWest, bearing{lat, lng}, bitDepth))
+	for lat := 0; lat <= latStep; lat++ {
+		for lng := 0; lng <= lngStep; lng++ {
+			output = append(output, NeighborInt(hashSouthWest, Bearing{lat, lng}, bitDepth))
 		}
 	}
 	return output
 }
 
+// BboxesIntCount will return the number of hash integers BboxesInt would return for the same arguments, without
+// generating them.
+func BboxesIntCount(minLat float64, minLon float64, maxLat float64, maxLon float64, bitDepth int64) int {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	_, latStep, lngStep := bboxSteps(minLat, minLon, maxLat, maxLon, bitDepth)
+	if latStep < 0 || lngStep < 0 {
+		return 0
+	}
+	return (latStep + 1) * (lngStep + 1)
+}
+
+// EstimateCoveringSize will return the number of cells a covering of minLat, minLon, maxLat, maxLon at bitDepth
+// contains and the approximate number of bytes its []int64 would take, without generating it.
+//
+// This is intended as a guardrail to reject a bbox before calling BboxesInt. The bytes are an estimate that covers
+// only the 8 bytes per cell, not any spare capacity or the slice header, and are capped at math.MaxInt rather than
+// overflowing. Unlike BboxesIntCount a bbox that crosses the antimeridian is not counted as empty, instead it counts
+// the cells of the parts either side of the antimeridian that would need to be covered separately.
+func EstimateCoveringSize(minLat float64, minLon float64, maxLat float64, maxLon float64, bitDepth int64) (cells int, bytes int) {
+	if BboxCrossesAntimeridian(minLon, maxLon) {
+		cells = BboxesIntCount(minLat, minLon, maxLat, 180, bitDepth) + BboxesIntCount(minLat, -180, maxLat, maxLon, bitDepth)
+	} else {
+		cells = BboxesIntCount(minLat, minLon, maxLat, maxLon, bitDepth)
+	}
+
+	if cells > math.MaxInt/8 {
+		return cells, math.MaxInt
+	}
+	return cells, 8 * cells
+}
+
+// bboxSteps returns the hash of the south west corner of a bbox along with the number of cells north and east of it
+// that are required to reach the north east corner.
+//
+// The steps are negative when the north east corner is south or west of the south west corner.
+func bboxSteps(minLat float64, minLon float64, maxLat float64, maxLon float64, bitDepth int64) (hashSouthWest int64, latStep int, lngStep int) {
+	// find the corners
+	hashSouthWest = EncodeInt(minLat, minLon, bitDepth)
+	hashNorthEast := EncodeInt(maxLat, maxLon, bitDepth)
+
+	latStep, lngStep = cellSteps(hashSouthWest, hashNorthEast, bitDepth)
+	return
+}
+
+// CellSpanInt will return the number of cells in each direction, inclusive of both corners, of the grid with
+// minHash as its south west corner and maxHash as its north east corner.
+//
+// For the corner cells of a bbox this is the number of rows and columns BboxesInt returns, so it can be used to size
+// a grid before filling it. Zero is returned for a direction in which maxHash is south or west of minHash.
+func CellSpanInt(minHash int64, maxHash int64, bitDepth int64) (latCells int, lngCells int) {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	latStep, lngStep := cellSteps(minHash, maxHash, bitDepth)
+	if latStep >= 0 {
+		latCells = latStep + 1
+	}
+	if lngStep >= 0 {
+		lngCells = lngStep + 1
+	}
+	return
+}
+
+// cellSteps returns the number of cells north and east of from that are required to reach to.
+func cellSteps(from int64, to int64, bitDepth int64) (latStep int, lngStep int) {
+	// count whole cells between the corners rather than dividing their sizes, so no rounding is required
+	fromLat, fromLng := deinterleave(from, bitDepth)
+	toLat, toLng := deinterleave(to, bitDepth)
+
+	return int(toLat - fromLat), int(toLng - fromLng)
+}
+
 // getBit returns the bit at the requested location
 func getBit(geohash int64, position int64) int64 {
 	return int64(geohash >> uint64(position)) & 0x01
@@ -246,46 +637,267 @@ func getBit(geohash int64, position int64) int64 {
 	// return int64(int((float64(geohash) / math.Pow(float64(2), float64(position)))) & 0x01)
 }
 
+// deinterleave splits a geohash integer into its latitude and longitude cell indexes.
+//
+// Longitude occupies the odd bit positions and latitude the even ones, so cell (0,0) is the south west corner
+// of the globe and each index runs from 0 to 2^(bitDepth/2) - 1.
+func deinterleave(geohash int64, bitDepth int64) (latIdx int64, lngIdx int64) {
+	steps := bitDepth / 2
+	for step := int64(0); step < steps; step++ {
+		latIdx |= getBit(geohash, step*2) << uint64(step)
+		lngIdx |= getBit(geohash, step*2+1) << uint64(step)
+	}
+	return
+}
+
+// interleave is the inverse of deinterleave and builds a geohash integer from latitude and longitude cell indexes.
+func interleave(latIdx int64, lngIdx int64, bitDepth int64) int64 {
+	var geohash int64
+	steps := bitDepth / 2
+	for step := int64(0); step < steps; step++ {
+		geohash |= getBit(latIdx, step) << uint64(step*2)
+		geohash |= getBit(lngIdx, step) << uint64(step*2+1)
+	}
+	return geohash
+}
+
 // FindBitDepth will attempt to find the maximum bitdepth which contains the supplied distance
+//
+// This is an alias of FindBitDepthCeil.
 func FindBitDepth(distanceMeters float64) int64 {
-	for key, value := range bitsToDistanceInMeters {
-		if value > distanceMeters {
-			return MaxBitDepth - (int64(key) * 2)
+	return FindBitDepthCeil(distanceMeters)
+}
+
+// FindBitDepth64 is the same as FindBitDepth but considers bit depths up to MaxBitDepth64 rather than MaxBitDepth, so
+// distances below the ~60cm cells of MaxBitDepth give a deeper bitDepth.
+//
+// For example FindBitDepth64(0.1) returns 56 (cells of ~30cm) where FindBitDepth returns MaxBitDepth.
+func FindBitDepth64(distanceMeters float64) int64 {
+	return findBitDepthCeil(distanceMeters, MaxBitDepth64)
+}
+
+// FindBitDepthCeil will return the maximum bitDepth (up to MaxBitDepth) whose cells are larger than the supplied
+// distance, so that a single cell always over-covers the distance.
+//
+// For example FindBitDepthCeil(100) returns 36 (cells of ~153m).
+// Returns 0 when the distance is larger than the cells of every bitDepth.
+func FindBitDepthCeil(distanceMeters float64) int64 {
+	return findBitDepthCeil(distanceMeters, MaxBitDepth)
+}
+
+// FindBitDepthFloor will return the minimum bitDepth (up to MaxBitDepth) whose cells are no larger than the supplied
+// distance, so that a cell always fits inside the distance.
+//
+// For example FindBitDepthFloor(100) returns 38 (cells of ~76m).
+// Returns 0 when the distance is smaller than the cells of every bitDepth.
+func FindBitDepthFloor(distanceMeters float64) int64 {
+	return findBitDepthFloor(distanceMeters, MaxBitDepth)
+}
+
+// findBitDepthCeil is FindBitDepthCeil for bit depths up to maxBitDepth.
+func findBitDepthCeil(distanceMeters float64, maxBitDepth int64) int64 {
+	for key := int((MaxBitDepth64 - maxBitDepth) / 2); key < len(bitsToDistanceInMeters); key++ {
+		if bitsToDistanceInMeters[key] > distanceMeters {
+			return MaxBitDepth64 - (int64(key) * 2)
 		}
 	}
 	return 0
 }
 
+// findBitDepthFloor is FindBitDepthFloor for bit depths up to maxBitDepth.
+func findBitDepthFloor(distanceMeters float64, maxBitDepth int64) int64 {
+	for key := len(bitsToDistanceInMeters) - 1; key >= int((MaxBitDepth64-maxBitDepth)/2); key-- {
+		if bitsToDistanceInMeters[key] <= distanceMeters {
+			return MaxBitDepth64 - (int64(key) * 2)
+		}
+	}
+	return 0
+}
+
+// BitDepthForPrecisionCM will return the minimum bitDepth whose cells are no larger than the supplied precision in
+// centimeters (as FindBitDepthFloor does, but up to MaxBitDepth64) along with the size of those cells in centimeters.
+//
+// An error is returned when even cells of MaxBitDepth64 are too large, along with MaxBitDepth64 and its precision
+// (~1.9cm) so the best achievable precision can be reported. Note that cells of MaxBitDepth are ~60cm.
+func BitDepthForPrecisionCM(cm float64) (int64, float64, error) {
+	bitDepth := findBitDepthFloor(cm/100, MaxBitDepth64)
+	if bitDepth == 0 {
+		best := bitsToDistanceInMeters[0] * 100
+		return MaxBitDepth64, best, fmt.Errorf("precision of %vcm cannot be represented, the best available is %vcm", cm, best)
+	}
+	return bitDepth, bitsToDistanceInMeters[(MaxBitDepth64-bitDepth)/2] * 100, nil
+}
+
+// BitDepthForGlobalCells will return the largest (even) bitDepth at which the whole globe is divided into no more
+// than targetCells cells, that is 2^bitDepth <= targetCells.
+//
+// For example BitDepthForGlobalCells(1000000) returns 18 (262,144 cells).
+// Targets below the 4 cells of bitDepth 2 return 2, and targets beyond MaxBitDepth return MaxBitDepth.
+func BitDepthForGlobalCells(targetCells int64) int64 {
+	bitDepth := int64(2)
+	for bitDepth+2 <= MaxBitDepth && int64(1)<<uint64(bitDepth+2) <= targetCells {
+		bitDepth += 2
+	}
+	return bitDepth
+}
+
 // Shift provides a convenient way to convert from MaxBitDepth to another
+//
+// Values with a bitDepth above MaxBitDepth are truncated to the MaxBitDepth cell that contains them.
+//
+// The value is not checked, so one with bits set above bitDepth (e.g. from corrupt data) overflows into the higher
+// bits and possibly the sign bit; use ShiftE to reject such values.
 func Shift(value int64, bitDepth int64) int64 {
 	// input validation
 	validateBitDepth(bitDepth)
 
+	if bitDepth > MaxBitDepth {
+		return value >> uint64(bitDepth-MaxBitDepth)
+	}
 	return value << uint64(MaxBitDepth-bitDepth)
 }
 
+// TruncateInt will return the geohash integer, at toDepth, of the coarser cell that contains a geohash integer at
+// fromDepth, which is the integer equivalent of shortening a geohash string.
+//
+// As each pair of bits adds one longitude and one latitude bit this drops the lowest fromDepth - toDepth bits.
+// A toDepth greater than fromDepth will cause panic().
+func TruncateInt(geohash int64, fromDepth int64, toDepth int64) int64 {
+	// input validation
+	validateBitDepth(fromDepth)
+	validateBitDepth(toDepth)
+	if toDepth > fromDepth {
+		panic(fmt.Sprintf("toDepth must be less than or equal to fromDepth %d, was %d", fromDepth, toDepth))
+	}
+
+	return geohash >> uint64(fromDepth-toDepth)
+}
+
+// ChildrenInt will return the four geohash integers, at bitDepth + 2, of the cells that a geohash integer's cell is
+// divided into. Child k is the one whose QuadrantInt is k: south west, north west, south east and then north east.
+//
+// A bitDepth of MaxBitDepth64 has no children and will cause panic().
+func ChildrenInt(geohash int64, bitDepth int64) []int64 {
+	// input validation
+	validateBitDepth(bitDepth)
+	validateBitDepth(bitDepth + 2)
+
+	return []int64{geohash << 2, geohash<<2 | 1, geohash<<2 | 2, geohash<<2 | 3}
+}
+
+// maxSubdivideBits is the largest difference between the bit depths given to SubdivideOdd.
+const maxSubdivideBits = 24
+
+// SubdivideOdd will return the 2^(toDepth - fromDepth) geohash integers, at toDepth, of the cells that a geohash
+// integer's cell at fromDepth is divided into, in ascending order. Unlike the rest of the package either bit depth may
+// be odd, as is needed for geohash strings where each character adds 5 bits.
+//
+// Bits alternate between longitude and latitude starting with longitude, so the bit added to an even bit depth splits
+// the cell in two by longitude (west then east) and the one added to an odd bit depth splits it by latitude (south
+// then north). An odd difference therefore gives twice as many cells in one direction as in the other. Bit depths
+// that are not between 1 and MaxBitDepth64, or a toDepth less than fromDepth, cause panic().
+//
+// The number of cells doubles with every bit, so a toDepth more than maxSubdivideBits (24, about 16 million cells)
+// finer than fromDepth also causes panic() rather than trying to allocate them; see ResampleCoveringLimit for
+// converting whole coverings with a limit on the number of cells.
+func SubdivideOdd(geohash int64, fromDepth int64, toDepth int64) []int64 {
+	// input validation
+	if fromDepth < 1 || toDepth > MaxBitDepth64 || toDepth < fromDepth {
+		panic(fmt.Sprintf("bit depths must be between 1 and %d with toDepth at least fromDepth, were %d and %d", MaxBitDepth64, fromDepth, toDepth))
+	}
+	if toDepth-fromDepth > maxSubdivideBits {
+		panic(fmt.Sprintf("toDepth can be at most %d bits finer than fromDepth, was %d", maxSubdivideBits, toDepth-fromDepth))
+	}
+
+	shift := uint64(toDepth - fromDepth)
+	output := make([]int64, 0, 1<<shift)
+	for child := geohash << shift; child < (geohash+1)<<shift; child++ {
+		output = append(output, child)
+	}
+	return output
+}
+
+// QuadrantInt will return which of its parent's four children (0 to 3) a geohash integer's cell is, see ChildrenInt.
+//
+// The quadrant is the lowest two bits of the geohash: the higher is the longitude bit (1 for the eastern half of the
+// parent) and the lower the latitude bit (1 for the northern half). So 0 is south west, 1 north west, 2 south east and
+// 3 north east, and chaining the quadrants from bitDepth 2 down gives a quadtree path to the cell.
+func QuadrantInt(geohash int64, bitDepth int64) int {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	return int(geohash & 0x03)
+}
+
+// ShiftE converts from bitDepth to MaxBitDepth as Shift does but returns an error, instead of causing panic() or
+// producing a corrupt (possibly negative) hash, when bitDepth is invalid or value has bits set outside of bitDepth.
+func ShiftE(value int64, bitDepth int64) (int64, error) {
+	// input validation
+	if err := checkBitDepth(bitDepth, MaxBitDepth64); err != nil {
+		return 0, err
+	}
+	if value < 0 || value >= int64(1)<<uint64(bitDepth) {
+		return 0, fmt.Errorf("geohash %d does not fit in bitDepth %d", value, bitDepth)
+	}
+	return Shift(value, bitDepth), nil
+}
+
 // validateBitDepth will ensure the supplied bitDepth is valid or cause panic() otherwise.
 func validateBitDepth(bitDepth int64) {
-	if bitDepth > MaxBitDepth || bitDepth <= 0 {
-		panic(fmt.Sprintf("bitDepth must be greater than 0 and less than or equal to %d, was %d", MaxBitDepth, bitDepth))
+	validateBitDepthMax(bitDepth, MaxBitDepth64)
+}
+
+// validateBitDepthMax will ensure the supplied bitDepth is valid and no more than maxBitDepth or cause panic() otherwise.
+func validateBitDepthMax(bitDepth int64, maxBitDepth int64) {
+	if err := checkBitDepth(bitDepth, maxBitDepth); err != nil {
+		panic(err.Error())
+	}
+}
+
+// checkBitDepth will return an error when the supplied bitDepth is not valid or is more than maxBitDepth.
+func checkBitDepth(bitDepth int64, maxBitDepth int64) error {
+	if bitDepth > maxBitDepth || bitDepth <= 0 {
+		return fmt.Errorf("bitDepth must be greater than 0 and less than or equal to %d, was %d", maxBitDepth, bitDepth)
 	}
 	if math.Mod(float64(bitDepth), float64(2)) != 0 {
-		panic(fmt.Sprintf("bitDepth must be even, was %d", bitDepth))
+		return fmt.Errorf("bitDepth must be even, was %d", bitDepth)
+	}
+	return nil
+}
+
+// validateCoordinates will ensure the supplied latitude and longitude are finite numbers or cause panic() otherwise.
+func validateCoordinates(latitude float64, longitude float64) {
+	if err := checkCoordinates(latitude, longitude); err != nil {
+		panic(err.Error())
+	}
+}
+
+// checkCoordinates will return an error when the supplied latitude or longitude is NaN or infinite.
+func checkCoordinates(latitude float64, longitude float64) error {
+	if math.IsNaN(latitude) || math.IsInf(latitude, 0) || math.IsNaN(longitude) || math.IsInf(longitude, 0) {
+		return fmt.Errorf("latitude and longitude must be finite, was %v,%v", latitude, longitude)
 	}
+	return nil
+}
+
+// Round will round val to the requested number of decimal places, with halves rounded away from zero.
+func Round(val float64, places int) float64 {
+	return round(val, 0.5, places)
 }
 
 // round is the "missing" round function from the math lib
+//
+// The value is rounded away from zero when the magnitude of its fraction (at the requested decimal places) is at
+// least roundOn, and toward zero otherwise, so positive and negative values round symmetrically.
 func round(val float64, roundOn float64, places int) float64 {
 	var round float64
 	pow := math.Pow(10, float64(places))
 	digit := pow * val
 	_, div := math.Modf(digit)
-	_div := math.Copysign(div, val)
-	_roundOn := math.Copysign(roundOn, val)
-	if _div >= _roundOn {
-		round = math.Ceil(digit)
+	if math.Abs(div) >= roundOn {
+		round = math.Copysign(math.Ceil(math.Abs(digit)), val)
 	} else {
-		round = math.Floor(digit)
+		round = math.Copysign(math.Floor(math.Abs(digit)), val)
 	}
 	return round / pow
 }
@@ -111,3 +111,1020 @@ func TestGetBit(t *testing.T) {
 		}
 	}
 }
+
+func TestInterleave(t *testing.T) {
+	for i := 0; i < 100; i++ { // 100 tests
+		geohash := rand.Int63n(1 << uint64(MaxBitDepth))
+		latIdx, lngIdx := deinterleave(geohash, MaxBitDepth)
+		result := interleave(latIdx, lngIdx, MaxBitDepth)
+		if geohash != result {
+			t.Errorf("interleave() failed with %v != %v", geohash, result)
+		}
+	}
+
+	// the south west corner cell
+	latIdx, lngIdx := deinterleave(EncodeInt(-89.999, -179.999, 32), 32)
+	if latIdx != 0 || lngIdx != 0 {
+		t.Errorf("Expected 0,0 but was %+v,%+v", latIdx, lngIdx)
+	}
+}
+
+func TestBboxesIntCount(t *testing.T) {
+	boxes := [][4]float64{
+		{30, 120, 30.0001, 120.0001},
+		{-10, -10, 10, 10},
+		{37.8, 112.5, 37.9, 112.6},
+		// crossing the antimeridian
+		{10, 179, 11, -179},
+	}
+	for _, box := range boxes {
+		for _, bitDepth := range []int64{10, 16, 20} {
+			expected := len(BboxesInt(box[0], box[1], box[2], box[3], bitDepth))
+			result := BboxesIntCount(box[0], box[1], box[2], box[3], bitDepth)
+			if expected != result {
+				t.Errorf("Expected %+v but was %+v for %+v at %d", expected, result, box, bitDepth)
+			}
+		}
+	}
+}
+
+func TestEstimateCoveringSize(t *testing.T) {
+	scenarios := []struct {
+		desc          string
+		box           [4]float64
+		bitDepth      int64
+		expectedCells int
+	}{
+		{desc: "small", box: [4]float64{37.8, 112.5, 37.9, 112.6}, bitDepth: 20, expectedCells: len(BboxesInt(37.8, 112.5, 37.9, 112.6, 20))},
+		{desc: "inverted", box: [4]float64{10, 10, -10, 11}, bitDepth: 20, expectedCells: 0},
+		{
+			desc:          "crossing the antimeridian",
+			box:           [4]float64{10, 179, 11, -179},
+			bitDepth:      20,
+			expectedCells: len(BboxesInt(10, 179, 11, 180, 20)) + len(BboxesInt(10, -180, 11, -179, 20)),
+		},
+		{desc: "whole globe", box: [4]float64{-90, -180, 90, 180}, bitDepth: 40, expectedCells: 1 << 40},
+		{desc: "too many bytes", box: [4]float64{-90, -180, 90, 180}, bitDepth: MaxBitDepth64, expectedCells: 1 << 62},
+	}
+
+	for _, scenario := range scenarios {
+		cells, bytes := EstimateCoveringSize(scenario.box[0], scenario.box[1], scenario.box[2], scenario.box[3], scenario.bitDepth)
+		expectedBytes := 8 * scenario.expectedCells
+		if scenario.expectedCells > math.MaxInt/8 {
+			expectedBytes = math.MaxInt
+		}
+		if scenario.expectedCells != cells || expectedBytes != bytes {
+			t.Errorf("%s: Expected %+v, %+v but was %+v, %+v", scenario.desc, scenario.expectedCells, expectedBytes, cells, bytes)
+		}
+	}
+}
+
+func TestEncodeIntE(t *testing.T) {
+	result, err := EncodeIntE(37.8324, 112.5584, MaxBitDepth)
+	if err != nil || result != 4064984913515641 {
+		t.Errorf("Expected %+v but was %+v (%v)", 4064984913515641, result, err)
+	}
+
+	_, err = EncodeIntE(math.NaN(), 112.5584, MaxBitDepth)
+	if err == nil {
+		t.Errorf("Expected an error for a NaN latitude")
+	}
+
+	_, err = EncodeIntE(37.8324, math.Inf(1), MaxBitDepth)
+	if err == nil {
+		t.Errorf("Expected an error for an infinite longitude")
+	}
+
+	_, err = EncodeIntE(37.8324, 112.5584, 33)
+	if err == nil {
+		t.Errorf("Expected an error for an odd bit depth")
+	}
+}
+
+func TestEncodeIntNaN(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected panic() for a NaN latitude")
+		}
+	}()
+	EncodeInt(math.NaN(), 112.5584, MaxBitDepth)
+}
+
+func TestFindBitDepth(t *testing.T) {
+	tests := []struct {
+		distance float64
+		ceil     int64
+		floor    int64
+	}{
+		{100, 36, 38},
+		{0.1, 52, 0},
+		{0.01, 52, 0},
+		{152.8757, 34, 36},
+		{20000000, 0, 4},
+	}
+	for _, test := range tests {
+		if result := FindBitDepth(test.distance); result != test.ceil {
+			t.Errorf("Expected FindBitDepth(%v) %+v but was %+v", test.distance, test.ceil, result)
+		}
+		if result := FindBitDepthCeil(test.distance); result != test.ceil {
+			t.Errorf("Expected FindBitDepthCeil(%v) %+v but was %+v", test.distance, test.ceil, result)
+		}
+		if result := FindBitDepthFloor(test.distance); result != test.floor {
+			t.Errorf("Expected FindBitDepthFloor(%v) %+v but was %+v", test.distance, test.floor, result)
+		}
+	}
+}
+
+func TestFindBitDepth64(t *testing.T) {
+	tests := []struct {
+		distance float64
+		expected int64
+	}{
+		{0, MaxBitDepth64},
+		{0.01, MaxBitDepth64},
+		{0.1, 56},
+		{0.2, 54},
+		{100, 36},
+		{20000000, 0},
+	}
+	for _, test := range tests {
+		if result := FindBitDepth64(test.distance); result != test.expected {
+			t.Errorf("Expected FindBitDepth64(%v) %+v but was %+v", test.distance, test.expected, result)
+		}
+	}
+
+	// FindBitDepth stays within MaxBitDepth for the APIs limited to it
+	if result := FindBitDepth(0); result != MaxBitDepth {
+		t.Errorf("Expected FindBitDepth(0) %+v but was %+v", MaxBitDepth, result)
+	}
+}
+
+func TestAreAdjacentInt(t *testing.T) {
+	center := int64(1702789509)
+	for _, neighbor := range NeighborsInt(center, 32) {
+		if neighbor == center {
+			if AreAdjacentInt(center, neighbor, 32) {
+				t.Errorf("Expected a cell not to be adjacent to itself")
+			}
+			continue
+		}
+		if !AreAdjacentInt(center, neighbor, 32) {
+			t.Errorf("Expected %+v to be adjacent to %+v", neighbor, center)
+		}
+	}
+
+	// two cells north
+	far := NeighborInt(NeighborInt(center, North, 32), North, 32)
+	if AreAdjacentInt(center, far, 32) {
+		t.Errorf("Expected %+v not to be adjacent to %+v", far, center)
+	}
+
+	// across the antimeridian
+	west := EncodeInt(10, 179.99, 20)
+	east := EncodeInt(10, -179.99, 20)
+	if !AreAdjacentInt(west, east, 20) {
+		t.Errorf("Expected %+v to be adjacent to %+v", east, west)
+	}
+}
+
+func TestBearingBetweenInt(t *testing.T) {
+	bearings := []Bearing{North, NorthEast, East, SouthEast, South, SouthWest, West, NorthWest}
+	for _, geohash := range []int64{1702789509, EncodeInt(-33.8688, 151.2093, 32)} {
+		for _, expected := range bearings {
+			result, ok := BearingBetweenInt(geohash, NeighborInt(geohash, expected, 32), 32)
+			if !ok || expected != result {
+				t.Errorf("Expected %+v but was %+v (%v)", expected, result, ok)
+			}
+		}
+	}
+
+	result, ok := BearingBetweenInt(1702789509, 1702789509, 32)
+	if ok || result != Center {
+		t.Errorf("Expected %+v and false but was %+v (%v)", Center, result, ok)
+	}
+
+	far := NeighborInt(NeighborInt(1702789509, North, 32), North, 32)
+	if _, ok := BearingBetweenInt(1702789509, far, 32); ok {
+		t.Errorf("Expected cells two apart not to have a bearing")
+	}
+
+	// across the antimeridian
+	result, ok = BearingBetweenInt(EncodeInt(10, 179.99, 20), EncodeInt(10, -179.99, 20), 20)
+	if !ok || result != East {
+		t.Errorf("Expected %+v but was %+v (%v)", East, result, ok)
+	}
+}
+
+func TestDecodeIntRounded(t *testing.T) {
+	lat, lng := DecodeIntRounded(4064984913515641, MaxBitDepth, 4)
+	if lat != 37.8324 || lng != 112.5584 {
+		t.Errorf("Expected %+v,%+v but was %+v,%+v", 37.8324, 112.5584, lat, lng)
+	}
+
+	lat, lng = DecodeIntRounded(4064984913515641, MaxBitDepth, 1)
+	if lat != 37.8 || lng != 112.6 {
+		t.Errorf("Expected %+v,%+v but was %+v,%+v", 37.8, 112.6, lat, lng)
+	}
+}
+
+func TestDecodeIntCorner(t *testing.T) {
+	scenarios := []struct {
+		desc        string
+		geohash     int64
+		bitDepth    int64
+		expectedLat float64
+		expectedLng float64
+	}{
+		{desc: "south west", geohash: 0, bitDepth: 2, expectedLat: -90, expectedLng: -180},
+		{desc: "north east", geohash: 3, bitDepth: 2, expectedLat: 0, expectedLng: 0},
+		{desc: "north west", geohash: 1, bitDepth: 2, expectedLat: 0, expectedLng: -180},
+		{desc: "quarter", geohash: 0x0f, bitDepth: 4, expectedLat: 45, expectedLng: 90},
+	}
+
+	for _, scenario := range scenarios {
+		lat, lng := DecodeIntCorner(scenario.geohash, scenario.bitDepth)
+		if scenario.expectedLat != lat || scenario.expectedLng != lng {
+			t.Errorf("%s: Expected %+v,%+v but was %+v,%+v", scenario.desc, scenario.expectedLat, scenario.expectedLng, lat, lng)
+		}
+	}
+
+	// the corner is on the boundary so encodes into the cell to the south west
+	geohash := EncodeInt(37.8324, 112.5584, MaxBitDepth)
+	lat, lng := DecodeIntCorner(geohash, MaxBitDepth)
+	if expected, result := NeighborInt(geohash, SouthWest, MaxBitDepth), EncodeInt(lat, lng, MaxBitDepth); expected != result {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
+
+func TestDisplayDecimals(t *testing.T) {
+	tests := []struct {
+		bitDepth int64
+		expected int
+	}{
+		{2, 0},
+		{8, 0},
+		{14, 1},
+		{20, 2},
+		{52, 6},
+		{MaxBitDepth64, 8},
+	}
+	for _, test := range tests {
+		if result := DisplayDecimals(test.bitDepth); test.expected != result {
+			t.Errorf("%d: Expected %+v but was %+v", test.bitDepth, test.expected, result)
+		}
+	}
+
+	// the last place shown is finer than the cell
+	for bitDepth := int64(2); bitDepth <= MaxBitDepth64; bitDepth += 2 {
+		_, _, latErr, _ := DecodeInt(0, bitDepth)
+		if decimals := DisplayDecimals(bitDepth); decimals > 0 && (math.Pow(10, -float64(decimals)) > latErr || math.Pow(10, -float64(decimals-1)) <= latErr) {
+			t.Errorf("%d: Expected %+v places to be the first smaller than %+v", bitDepth, decimals, latErr)
+		}
+	}
+}
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		val      float64
+		places   int
+		expected float64
+	}{
+		{37.83245, 0, 38},
+		{37.83245, 2, 37.83},
+		{37.83745, 2, 37.84},
+		{-37.83245, 2, -37.83},
+		{-37.83745, 2, -37.84},
+		{1234.5, -2, 1200},
+	}
+	for _, test := range tests {
+		if result := Round(test.val, test.places); result != test.expected {
+			t.Errorf("Expected Round(%v, %d) %+v but was %+v", test.val, test.places, test.expected, result)
+		}
+	}
+}
+
+func TestRoundHelper(t *testing.T) {
+	tests := []struct {
+		val      float64
+		roundOn  float64
+		places   int
+		expected float64
+	}{
+		{2.4, 0.5, 0, 2},
+		{2.5, 0.5, 0, 3},
+		{2.6, 0.5, 0, 3},
+		{-2.4, 0.5, 0, -2},
+		{-2.5, 0.5, 0, -3},
+		{-2.6, 0.5, 0, -3},
+		{3, 0.5, 0, 3},
+		{-3, 0.5, 0, -3},
+		{0, 0.5, 0, 0},
+		{2.25, 0.5, 1, 2.3},
+		{-2.25, 0.5, 1, -2.3},
+		{2.2, 0.1, 0, 3},
+		{-2.2, 0.1, 0, -3},
+	}
+	for _, test := range tests {
+		if result := round(test.val, test.roundOn, test.places); result != test.expected {
+			t.Errorf("Expected round(%v, %v, %d) %+v but was %+v", test.val, test.roundOn, test.places, test.expected, result)
+		}
+	}
+}
+
+func TestBBoxesIntEdges(t *testing.T) {
+	// corners that fall exactly on, or just either side of, cell boundaries
+	boxes := [][4]float64{
+		{0, 0, 11.25, 22.5},
+		{-11.25, -22.5, 0, 0},
+		{0.0000001, 0.0000001, 11.2500001, 22.5000001},
+		{-89.9, -179.9, -60.1, -130.1},
+		{60.1, 130.1, 90, 180},
+		{-5.6, -11.2, 5.6, 11.2},
+	}
+	for _, box := range boxes {
+		results := BboxesInt(box[0], box[1], box[2], box[3], 10)
+
+		found := map[int64]bool{}
+		for _, resultValue := range results {
+			found[resultValue] = true
+		}
+
+		// every cell between the corner cells must be present
+		swLat, swLng := deinterleave(EncodeInt(box[0], box[1], 10), 10)
+		neLat, neLng := deinterleave(EncodeInt(box[2], box[3], 10), 10)
+		expected := int((neLat - swLat + 1) * (neLng - swLng + 1))
+		if len(results) != expected || len(found) != expected {
+			t.Errorf("Expected %+v cells but was %+v (%+v distinct) for %+v", expected, len(results), len(found), box)
+		}
+		for latIdx := swLat; latIdx <= neLat; latIdx++ {
+			for lngIdx := swLng; lngIdx <= neLng; lngIdx++ {
+				if !found[interleave(latIdx, lngIdx, 10)] {
+					t.Errorf("Expected cell %+v,%+v to be found for %+v", latIdx, lngIdx, box)
+				}
+			}
+		}
+	}
+}
+
+func TestNeighborhoodsInt(t *testing.T) {
+	centers := []int64{1702789509, EncodeInt(-33.8688, 151.2093, 32)}
+	results := NeighborhoodsInt(centers, 1, 32)
+
+	if len(results) != len(centers) {
+		t.Fatalf("Expected %+v neighborhoods but was %+v", len(centers), len(results))
+	}
+	for index, center := range centers {
+		expected := NeighborsInt(center, 32)
+		if len(results[index]) != len(expected) {
+			t.Errorf("Expected %+v cells but was %+v", len(expected), len(results[index]))
+		}
+		for _, expectedValue := range expected {
+			found := false
+			for _, resultValue := range results[index] {
+				if expectedValue == resultValue {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("Expected value %+v not found.", expectedValue)
+			}
+		}
+	}
+
+	results = NeighborhoodsInt(centers, 2, 32)
+	if len(results[0]) != 25 {
+		t.Errorf("Expected %+v cells but was %+v", 25, len(results[0]))
+	}
+
+	// the northern most row is clamped at the pole and deduplicated
+	results = NeighborhoodsInt([]int64{EncodeInt(89.99, 0, 10)}, 1, 10)
+	if len(results[0]) != 6 {
+		t.Errorf("Expected %+v cells but was %+v", 6, len(results[0]))
+	}
+
+	// longitude wraps around the antimeridian, with the same edge neighbors as EdgeNeighborsInt
+	center := EncodeInt(10, 179.9999, 32)
+	results = NeighborhoodsInt([]int64{center}, 1, 32)
+	if len(results[0]) != 9 {
+		t.Errorf("Expected %+v cells but was %+v", 9, len(results[0]))
+	}
+	cells := toSet(results[0])
+	for _, neighbor := range EdgeNeighborsInt(center, 32) {
+		if !cells[neighbor] {
+			t.Errorf("Expected edge neighbor %+v in %+v", neighbor, results[0])
+		}
+	}
+	if east := EncodeInt(10, -179.9999, 32); !cells[east] {
+		t.Errorf("Expected %+v across the antimeridian in %+v", east, results[0])
+	}
+}
+
+func TestBitDepthForPrecisionCM(t *testing.T) {
+	tests := []struct {
+		cm               float64
+		expectedBitDepth int64
+		expectedCM       float64
+	}{
+		{100, 52, 59.71},
+		{59.71, 52, 59.71},
+		{10, 58, 7.46},
+		{2, MaxBitDepth64, 1.87},
+	}
+	for _, test := range tests {
+		bitDepth, cm, err := BitDepthForPrecisionCM(test.cm)
+		if err != nil || test.expectedBitDepth != bitDepth || !ApproxEqual(test.expectedCM, cm, 1e-9) {
+			t.Errorf("%v: Expected %+v,%+v but was %+v,%+v (%v)", test.cm, test.expectedBitDepth, test.expectedCM, bitDepth, cm, err)
+		}
+	}
+
+	bitDepth, cm, err := BitDepthForPrecisionCM(1)
+	if err == nil || bitDepth != MaxBitDepth64 || !ApproxEqual(1.87, cm, 1e-9) {
+		t.Errorf("Expected an error with %+v,%+v but was %+v,%+v (%v)", MaxBitDepth64, 1.87, bitDepth, cm, err)
+	}
+}
+
+func TestBitDepthForGlobalCells(t *testing.T) {
+	tests := []struct {
+		targetCells int64
+		expected    int64
+	}{
+		{0, 2},
+		{4, 2},
+		{15, 2},
+		{16, 4},
+		{1000000, 18},
+		{1 << 20, 20},
+		{math.MaxInt64, MaxBitDepth},
+	}
+	for _, test := range tests {
+		if result := BitDepthForGlobalCells(test.targetCells); result != test.expected {
+			t.Errorf("Expected BitDepthForGlobalCells(%v) %+v but was %+v", test.targetCells, test.expected, result)
+		}
+	}
+}
+
+func TestEncodeDecodeIntExtendedBitDepth(t *testing.T) {
+	points := []Point{{37.8324, 112.5584}, {-33.8688, 151.2093}, {89.9999999, -179.9999999}, {0, 0}}
+	for _, bitDepth := range []int64{54, 56, 60, MaxBitDepth64} {
+		for _, point := range points {
+			geohash := EncodeInt(point.Lat, point.Lng, bitDepth)
+			if geohash < 0 || geohash >= int64(1)<<uint64(bitDepth) {
+				t.Errorf("Expected a %d bit value but was %+v", bitDepth, geohash)
+			}
+
+			lat, lng, latErr, lngErr := DecodeInt(geohash, bitDepth)
+			if !ApproxEqual(point.Lat, lat, latErr) || !ApproxEqual(point.Lng, lng, lngErr) {
+				t.Errorf("Expected %+v,%+v but was %+v,%+v at %d", point.Lat, point.Lng, lat, lng, bitDepth)
+			}
+
+			// each extra pair of bits halves the error
+			_, _, expectedErr, _ := DecodeInt(Shift(geohash, bitDepth), MaxBitDepth)
+			if ratio := expectedErr / latErr; ratio != float64(int64(1)<<uint64((bitDepth-MaxBitDepth)/2)) {
+				t.Errorf("Expected the error to halve with every 2 bits but the ratio was %+v at %d", ratio, bitDepth)
+			}
+		}
+	}
+}
+
+func TestShift(t *testing.T) {
+	geohash := EncodeInt(37.8324, 112.5584, 32)
+	expected := EncodeInt(37.8324, 112.5584, MaxBitDepth) >> 20 << 20
+	if result := Shift(geohash, 32); expected != result {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+
+	// finer hashes are truncated
+	expected = EncodeInt(37.8324, 112.5584, MaxBitDepth)
+	if result := Shift(EncodeInt(37.8324, 112.5584, 60), 60); expected != result {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
+
+func TestTruncateInt(t *testing.T) {
+	points := [][2]float64{{37.8324, 112.5584}, {-33.8688, 151.2093}, {0, 0}, {90, 180}, {-90, -180}}
+
+	for _, point := range points {
+		geohash := EncodeInt(point[0], point[1], MaxBitDepth)
+		for toDepth := int64(2); toDepth <= MaxBitDepth; toDepth += 2 {
+			result := TruncateInt(geohash, MaxBitDepth, toDepth)
+			if expected := EncodeInt(point[0], point[1], toDepth); expected != result {
+				t.Errorf("Expected %+v but was %+v", expected, result)
+			}
+
+			minLat, minLng, maxLat, maxLng := DecodeBboxInt(result, toDepth)
+			if point[0] < minLat || point[0] > maxLat || point[1] < minLng || point[1] > maxLng {
+				t.Errorf("Expected %+v to contain %+v", result, point)
+			}
+		}
+	}
+}
+
+func TestTruncateIntFiner(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected panic() for a finer toDepth")
+		}
+	}()
+	TruncateInt(1, 20, 22)
+}
+
+func TestChildrenInt(t *testing.T) {
+	parent := EncodeInt(37.8324, 112.5584, 30)
+	lat, lng, latErr, lngErr := DecodeInt(parent, 30)
+
+	// south west, north west, south east and north east
+	expected := []int64{
+		EncodeInt(lat-latErr/2, lng-lngErr/2, 32),
+		EncodeInt(lat+latErr/2, lng-lngErr/2, 32),
+		EncodeInt(lat-latErr/2, lng+lngErr/2, 32),
+		EncodeInt(lat+latErr/2, lng+lngErr/2, 32),
+	}
+	results := ChildrenInt(parent, 30)
+	if len(expected) != len(results) {
+		t.Fatalf("Expected %+v but was %+v", expected, results)
+	}
+	for index := range expected {
+		if expected[index] != results[index] {
+			t.Errorf("Expected %+v but was %+v", expected, results)
+		}
+	}
+
+	for k, child := range results {
+		if quadrant := QuadrantInt(child, 32); quadrant != k {
+			t.Errorf("Expected %+v but was %+v", k, quadrant)
+		}
+		if truncated := TruncateInt(child, 32, 30); truncated != parent {
+			t.Errorf("Expected %+v but was %+v", parent, truncated)
+		}
+	}
+}
+
+func TestChildrenIntMaxBitDepth(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected panic() for a bitDepth without children")
+		}
+	}()
+	ChildrenInt(1, MaxBitDepth64)
+}
+
+func TestShiftE(t *testing.T) {
+	geohash := EncodeInt(37.8324, 112.5584, 32)
+	result, err := ShiftE(geohash, 32)
+	if err != nil || Shift(geohash, 32) != result {
+		t.Errorf("Expected %+v but was %+v (%v)", Shift(geohash, 32), result, err)
+	}
+
+	scenarios := []struct {
+		desc     string
+		value    int64
+		bitDepth int64
+	}{
+		{desc: "high bits set", value: 1 << 40, bitDepth: 32},
+		{desc: "overflows the sign bit", value: 1<<32 - 1 | 1<<40, bitDepth: 20},
+		{desc: "negative", value: -1, bitDepth: 32},
+		{desc: "invalid bit depth", value: 1, bitDepth: 33},
+	}
+
+	for _, scenario := range scenarios {
+		if result, err := ShiftE(scenario.value, scenario.bitDepth); err == nil {
+			t.Errorf("%s: Expected an error but was %+v", scenario.desc, result)
+		}
+	}
+}
+
+func TestDecodeIntInto(t *testing.T) {
+	var result DecodedCell
+	DecodeIntInto(4064984913515641, MaxBitDepth, &result)
+
+	lat, lng, latErr, lngErr := DecodeInt(4064984913515641, MaxBitDepth)
+	expected := DecodedCell{Lat: lat, Lng: lng, LatErr: latErr, LngErr: lngErr}
+	if expected != result {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+
+	// the same as DecodeInt at every bit depth, including the edges of the globe
+	for bitDepth := int64(2); bitDepth <= MaxBitDepth64; bitDepth += 2 {
+		for _, point := range []Point{{Lat: 37.8324, Lng: 112.5584}, {Lat: -90, Lng: -180}, {Lat: 90, Lng: 180}, {Lat: -0.0001, Lng: 0.0001}} {
+			geohash := EncodeInt(point.Lat, point.Lng, bitDepth)
+			DecodeIntInto(geohash, bitDepth, &result)
+
+			lat, lng, latErr, lngErr := DecodeInt(geohash, bitDepth)
+			if expected := (DecodedCell{Lat: lat, Lng: lng, LatErr: latErr, LngErr: lngErr}); expected != result {
+				t.Errorf("%d: Expected %+v but was %+v", bitDepth, expected, result)
+			}
+		}
+	}
+}
+
+func BenchmarkDecodeInt(b *testing.B) {
+	var lat, lng, latErr, lngErr float64
+	for i := 0; i < b.N; i++ {
+		lat, lng, latErr, lngErr = DecodeInt(4064984913515641, MaxBitDepth)
+	}
+	_, _, _, _ = lat, lng, latErr, lngErr
+}
+
+func BenchmarkDecodeIntInto(b *testing.B) {
+	var result DecodedCell
+	for i := 0; i < b.N; i++ {
+		DecodeIntInto(4064984913515641, MaxBitDepth, &result)
+	}
+}
+
+func TestBitNeighborsInt(t *testing.T) {
+	results := BitNeighborsInt(0x0b, 4)
+	expected := []int64{0x03, 0x0f, 0x09, 0x0a}
+	if len(results) != len(expected) {
+		t.Fatalf("Expected %+v but was %+v", expected, results)
+	}
+	for index := range expected {
+		if expected[index] != results[index] {
+			t.Errorf("Expected %+v but was %+v", expected, results)
+		}
+	}
+
+	geohash := EncodeInt(37.8324, 112.5584, 32)
+	for _, result := range BitNeighborsInt(geohash, 32) {
+		if differing := result ^ geohash; differing&(differing-1) != 0 || result >= 1<<32 {
+			t.Errorf("Expected %+v to differ from %+v by exactly one bit", result, geohash)
+		}
+	}
+}
+
+func TestGridDistanceInt(t *testing.T) {
+	geohash := EncodeInt(37.8324, 112.5584, 32)
+
+	scenarios := []struct {
+		desc       string
+		from       int64
+		to         int64
+		expectedDx int
+		expectedDy int
+	}{
+		{desc: "same", from: geohash, to: geohash, expectedDx: 0, expectedDy: 0},
+		{desc: "north", from: geohash, to: NeighborInt(geohash, North, 32), expectedDx: 0, expectedDy: 1},
+		{desc: "east", from: geohash, to: NeighborInt(geohash, East, 32), expectedDx: 1, expectedDy: 0},
+		{desc: "south west", from: geohash, to: NeighborInt(geohash, SouthWest, 32), expectedDx: -1, expectedDy: -1},
+		{desc: "far", from: geohash, to: NeighborInt(NeighborInt(NeighborInt(geohash, East, 32), East, 32), SouthEast, 32), expectedDx: 3, expectedDy: -1},
+		{desc: "antimeridian east", from: EncodeInt(0.1, 179.999, 32), to: EncodeInt(0.1, -179.999, 32), expectedDx: 1, expectedDy: 0},
+		{desc: "antimeridian west", from: EncodeInt(0.1, -179.999, 32), to: EncodeInt(0.1, 179.999, 32), expectedDx: -1, expectedDy: 0},
+	}
+
+	for _, scenario := range scenarios {
+		dx, dy := GridDistanceInt(scenario.from, scenario.to, 32)
+		if scenario.expectedDx != dx || scenario.expectedDy != dy {
+			t.Errorf("%s: Expected %+v,%+v but was %+v,%+v", scenario.desc, scenario.expectedDx, scenario.expectedDy, dx, dy)
+		}
+	}
+}
+
+func TestEncodeIntBoundaries(t *testing.T) {
+	// the cells to the south west of the origin at depth 52 are the last of the lower halves of each axis
+	center := int64(1)<<uint64(MaxBitDepth/2-1) - 1
+	origin := interleave(center, center, MaxBitDepth)
+
+	scenarios := []struct {
+		desc     string
+		lat      float64
+		lng      float64
+		bitDepth int64
+		expected int64
+	}{
+		{desc: "origin depth 2", lat: 0, lng: 0, bitDepth: 2, expected: 0},
+		{desc: "origin", lat: 0, lng: 0, bitDepth: MaxBitDepth, expected: origin},
+		{desc: "negative zero", lat: math.Copysign(0, -1), lng: math.Copysign(0, -1), bitDepth: MaxBitDepth, expected: origin},
+		{desc: "just north east of origin", lat: math.Nextafter(0, 1), lng: math.Nextafter(0, 1), bitDepth: MaxBitDepth, expected: interleave(center+1, center+1, MaxBitDepth)},
+		{desc: "north east corner", lat: 90, lng: 180, bitDepth: MaxBitDepth, expected: 1<<MaxBitDepth - 1},
+		{desc: "south west corner", lat: -90, lng: -180, bitDepth: MaxBitDepth, expected: 0},
+		{desc: "equator and antimeridian", lat: 0, lng: 180, bitDepth: 4, expected: 0x0b},
+		{desc: "cell boundary", lat: 45, lng: 90, bitDepth: 4, expected: 0x0c},
+	}
+
+	for _, scenario := range scenarios {
+		result := EncodeInt(scenario.lat, scenario.lng, scenario.bitDepth)
+		if scenario.expected != result {
+			t.Errorf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected, result)
+		}
+	}
+}
+
+func TestStepInt(t *testing.T) {
+	geohash := EncodeInt(37.8324, 112.5584, 32)
+
+	scenarios := []struct {
+		desc     string
+		geohash  int64
+		dLat     int
+		dLng     int
+		expected int64
+	}{
+		{desc: "none", geohash: geohash, dLat: 0, dLng: 0, expected: geohash},
+		{desc: "north", geohash: geohash, dLat: 1, dLng: 0, expected: NeighborInt(geohash, North, 32)},
+		{desc: "south west", geohash: geohash, dLat: -1, dLng: -1, expected: NeighborInt(geohash, SouthWest, 32)},
+		{desc: "far", geohash: geohash, dLat: -1, dLng: 3, expected: NeighborInt(NeighborInt(NeighborInt(geohash, East, 32), East, 32), SouthEast, 32)},
+		{desc: "antimeridian", geohash: EncodeInt(0.1, 179.999, 32), dLat: 0, dLng: 2, expected: NeighborInt(EncodeInt(0.1, -179.999, 32), East, 32)},
+		{desc: "whole way around", geohash: geohash, dLat: 0, dLng: -1 << 16, expected: geohash},
+		{desc: "beyond the north pole", geohash: EncodeInt(89.999, 0.1, 32), dLat: 5, dLng: 0, expected: EncodeInt(90, 0.1, 32)},
+		{desc: "beyond the south pole", geohash: EncodeInt(-89.999, 0.1, 32), dLat: -5, dLng: 1, expected: NeighborInt(EncodeInt(-90, 0.1, 32), East, 32)},
+	}
+
+	for _, scenario := range scenarios {
+		result := StepInt(scenario.geohash, scenario.dLat, scenario.dLng, 32)
+		if scenario.expected != result {
+			t.Errorf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected, result)
+		}
+	}
+}
+
+func TestOffsetInt(t *testing.T) {
+	geohash := EncodeInt(37.8324, 112.5584, 32)
+	cellWidth := 360.0 / (1 << 16)
+
+	scenarios := []struct {
+		desc     string
+		geohash  int64
+		dLat     float64
+		dLng     float64
+		expected int64
+	}{
+		{desc: "none", geohash: geohash, dLat: 0, dLng: 0, expected: geohash},
+		{desc: "north east", geohash: geohash, dLat: 1.5, dLng: 2.5, expected: EncodeInt(39.3324, 115.0584, 32)},
+		{desc: "south west", geohash: geohash, dLat: -40, dLng: -200, expected: EncodeInt(-2.1676, -87.4416, 32)},
+		{desc: "antimeridian east", geohash: EncodeInt(10, 179.5, 32), dLat: 0, dLng: 200 * cellWidth, expected: StepInt(EncodeInt(10, 179.5, 32), 0, 200, 32)},
+		{desc: "antimeridian west", geohash: EncodeInt(10, -179.5, 32), dLat: 0, dLng: -200 * cellWidth, expected: StepInt(EncodeInt(10, -179.5, 32), 0, -200, 32)},
+		{desc: "whole way around", geohash: geohash, dLat: 0, dLng: 720, expected: geohash},
+		{desc: "north pole", geohash: geohash, dLat: 100, dLng: 0, expected: EncodeInt(90, 112.5584, 32)},
+		{desc: "south pole", geohash: geohash, dLat: -200, dLng: 0, expected: EncodeInt(-90, 112.5584, 32)},
+	}
+
+	for _, scenario := range scenarios {
+		result := OffsetInt(scenario.geohash, scenario.dLat, scenario.dLng, 32)
+		if scenario.expected != result {
+			t.Errorf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected, result)
+		}
+	}
+}
+
+func TestSubdivideOdd(t *testing.T) {
+	// adding a geohash character splits a cell into 32 children of 5 more bits, in the order of the alphabet
+	parent, parentDepth, _ := ParseHash("ww8p", "")
+	result := SubdivideOdd(parent, parentDepth, parentDepth+bitsPerChar)
+	if len(result) != 32 {
+		t.Fatalf("Expected %+v children but was %+v", 32, len(result))
+	}
+	for index, child := range result {
+		// an odd bit depth cell is the union of its two halves at the next (even) bit depth
+		minLat, minLng, _, _ := DecodeBboxInt(child<<1, parentDepth+bitsPerChar+1)
+		_, _, maxLat, maxLng := DecodeBboxInt(child<<1|1, parentDepth+bitsPerChar+1)
+
+		geohash := "ww8p" + string(base32[index])
+		expectedMinLat, expectedMinLng, expectedMaxLat, expectedMaxLng, _ := DecodeBboxString(geohash)
+		if expectedMinLat != minLat || expectedMinLng != minLng || expectedMaxLat != maxLat || expectedMaxLng != maxLng {
+			t.Errorf("%s: Expected %+v,%+v,%+v,%+v but was %+v,%+v,%+v,%+v", geohash, expectedMinLat, expectedMinLng, expectedMaxLat, expectedMaxLng, minLat, minLng, maxLat, maxLng)
+		}
+	}
+
+	// one bit from an even bit depth splits by longitude and the next by latitude, giving the same cells as ChildrenInt
+	geohash := EncodeInt(37.8324, 112.5584, 20)
+	var grandchildren []int64
+	for _, child := range SubdivideOdd(geohash, 20, 21) {
+		grandchildren = append(grandchildren, SubdivideOdd(child, 21, 22)...)
+	}
+	children := ChildrenInt(geohash, 20)
+	for index := range children {
+		if children[index] != grandchildren[index] {
+			t.Errorf("Expected %+v but was %+v", children, grandchildren)
+		}
+	}
+
+	if result := SubdivideOdd(geohash, 20, 20); len(result) != 1 || result[0] != geohash {
+		t.Errorf("Expected %+v but was %+v", []int64{geohash}, result)
+	}
+}
+
+func TestSubdivideOddLimit(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic")
+		}
+	}()
+	SubdivideOdd(1, 1, 2+maxSubdivideBits)
+}
+
+func TestAntipodeInt(t *testing.T) {
+	scenarios := []struct {
+		desc     string
+		lat      float64
+		lng      float64
+		expected Point
+	}{
+		{desc: "northern hemisphere", lat: 37.8324, lng: 112.5584, expected: Point{Lat: -37.8324, Lng: -67.4416}},
+		{desc: "western hemisphere", lat: -33.8688, lng: -151.2093, expected: Point{Lat: 33.8688, Lng: 28.7907}},
+		{desc: "antimeridian", lat: 10, lng: 179.9999, expected: Point{Lat: -10, Lng: -0.0001}},
+		{desc: "prime meridian", lat: 10, lng: 0.0001, expected: Point{Lat: -10, Lng: -179.9999}},
+		{desc: "north pole", lat: 90, lng: 180, expected: Point{Lat: -90, Lng: -1e-9}},
+	}
+
+	for _, scenario := range scenarios {
+		for bitDepth := int64(2); bitDepth <= MaxBitDepth; bitDepth += 10 {
+			geohash := EncodeInt(scenario.lat, scenario.lng, bitDepth)
+			expected := EncodeInt(scenario.expected.Lat, scenario.expected.Lng, bitDepth)
+
+			result := AntipodeInt(geohash, bitDepth)
+			if expected != result {
+				t.Errorf("%s at %d: Expected %+v but was %+v", scenario.desc, bitDepth, expected, result)
+			}
+			if back := AntipodeInt(result, bitDepth); geohash != back {
+				t.Errorf("%s at %d: Expected %+v but was %+v", scenario.desc, bitDepth, geohash, back)
+			}
+		}
+	}
+}
+
+func TestCellTransitionInt(t *testing.T) {
+	scenarios := []struct {
+		desc            string
+		prev            Point
+		cur             Point
+		expectedChanged bool
+		expectedBearing Bearing
+	}{
+		{desc: "same cell", prev: Point{Lat: 37.8324, Lng: 112.5584}, cur: Point{Lat: 37.8325, Lng: 112.5585}, expectedChanged: false, expectedBearing: Center},
+		{desc: "north", prev: Point{Lat: 37.8324, Lng: 112.5584}, cur: Point{Lat: 38.1, Lng: 112.5584}, expectedChanged: true, expectedBearing: North},
+		{desc: "far south west", prev: Point{Lat: 37.8324, Lng: 112.5584}, cur: Point{Lat: 30, Lng: 100}, expectedChanged: true, expectedBearing: SouthWest},
+		{desc: "across the antimeridian", prev: Point{Lat: 10.01, Lng: 179.99}, cur: Point{Lat: 10.01, Lng: -179.99}, expectedChanged: true, expectedBearing: East},
+	}
+
+	for _, scenario := range scenarios {
+		from, to, changed, bearing := CellTransitionInt(scenario.prev.Lat, scenario.prev.Lng, scenario.cur.Lat, scenario.cur.Lng, 20)
+		expectedFrom := EncodeInt(scenario.prev.Lat, scenario.prev.Lng, 20)
+		expectedTo := EncodeInt(scenario.cur.Lat, scenario.cur.Lng, 20)
+		if expectedFrom != from || expectedTo != to {
+			t.Errorf("%s: Expected %+v,%+v but was %+v,%+v", scenario.desc, expectedFrom, expectedTo, from, to)
+		}
+		if scenario.expectedChanged != changed || scenario.expectedBearing != bearing {
+			t.Errorf("%s: Expected %+v,%+v but was %+v,%+v", scenario.desc, scenario.expectedChanged, scenario.expectedBearing, changed, bearing)
+		}
+	}
+}
+
+func TestCellSpanInt(t *testing.T) {
+	scenarios := []struct {
+		desc   string
+		minLat float64
+		minLng float64
+		maxLat float64
+		maxLng float64
+	}{
+		{desc: "small", minLat: 30, minLng: 120, maxLat: 30.01, maxLng: 120.02},
+		{desc: "on boundaries", minLat: 0, minLng: 0, maxLat: 180.0 / 8192 * 2, maxLng: 360.0 / 8192 * 3},
+		{desc: "single cell", minLat: 30.001, minLng: 120.001, maxLat: 30.001, maxLng: 120.001},
+	}
+
+	for _, scenario := range scenarios {
+		minHash := EncodeInt(scenario.minLat, scenario.minLng, 26)
+		maxHash := EncodeInt(scenario.maxLat, scenario.maxLng, 26)
+
+		latCells, lngCells := CellSpanInt(minHash, maxHash, 26)
+		hashes := BboxesInt(scenario.minLat, scenario.minLng, scenario.maxLat, scenario.maxLng, 26)
+		if latCells*lngCells != len(hashes) {
+			t.Errorf("%s: Expected %+v cells but was %+v", scenario.desc, len(hashes), latCells*lngCells)
+		}
+
+		// the last cell of each row is the row's north east most
+		for row := 0; row < latCells; row++ {
+			if expected := StepInt(minHash, row, lngCells-1, 26); expected != hashes[row*lngCells+lngCells-1] {
+				t.Errorf("%s: Expected %+v but was %+v", scenario.desc, expected, hashes[row*lngCells+lngCells-1])
+			}
+		}
+	}
+
+	latCells, lngCells := CellSpanInt(EncodeInt(10, 10, 26), EncodeInt(9, 11, 26), 26)
+	if latCells != 0 || lngCells == 0 {
+		t.Errorf("Expected no rows but was %+v,%+v", latCells, lngCells)
+	}
+}
+
+func TestNeighborsIntInto(t *testing.T) {
+	buffer := make([]int64, 3, 9)
+	result := NeighborsIntInto(1702789509, 32, buffer)
+
+	expected := NeighborsInt(1702789509, 32)
+	if len(expected) != len(result) {
+		t.Fatalf("Expected %+v but was %+v", expected, result)
+	}
+	for index := range expected {
+		if expected[index] != result[index] {
+			t.Errorf("Expected %+v but was %+v", expected, result)
+		}
+	}
+	if &buffer[0] != &result[0] {
+		t.Errorf("Expected the buffer to be reused")
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { result = NeighborsIntInto(1702789509, 32, result) }); allocs != 0 {
+		t.Errorf("Expected %+v allocations but was %+v", 0, allocs)
+	}
+}
+
+func TestNeighborsIntIntoMatchesNeighborInt(t *testing.T) {
+	bearings := []Bearing{North, NorthEast, East, SouthEast, South, SouthWest, West, NorthWest}
+	points := [][2]float64{{-33.8688, 151.2093}, {90, 180}, {-90, -180}, {90, 0}, {0, -180}, {57.64911, 10.40744}}
+	for _, bitDepth := range []int64{2, 4, 20, 32, MaxBitDepth, MaxBitDepth64} {
+		for _, point := range points {
+			geohash := EncodeInt(point[0], point[1], bitDepth)
+			result := NeighborsIntInto(geohash, bitDepth, nil)
+			for index, bearing := range bearings {
+				if expected := NeighborInt(geohash, bearing, bitDepth); expected != result[index] {
+					t.Errorf("%d %+v %+v: Expected %+v but was %+v", bitDepth, point, bearing, expected, result[index])
+				}
+			}
+			if result[8] != geohash {
+				t.Errorf("%d %+v: Expected %+v but was %+v", bitDepth, point, geohash, result[8])
+			}
+		}
+	}
+}
+
+func TestSurroundingInt(t *testing.T) {
+	expected := NeighborsInt(1702789509, 32)[:8]
+	result := SurroundingInt(1702789509, 32)
+	if len(expected) != len(result) {
+		t.Fatalf("Expected %+v but was %+v", expected, result)
+	}
+	for index := range expected {
+		if expected[index] != result[index] {
+			t.Errorf("Expected %+v but was %+v", expected, result)
+		}
+	}
+	for _, geohash := range result {
+		if geohash == 1702789509 {
+			t.Errorf("Expected %+v to not include the center", result)
+		}
+	}
+}
+
+func TestEdgeNeighborsInt(t *testing.T) {
+	scenarios := []struct {
+		desc     string
+		geohash  int64
+		expected []int64
+	}{
+		{
+			desc:    "typical",
+			geohash: 1702789509,
+			expected: []int64{
+				NeighborInt(1702789509, North, 32), NeighborInt(1702789509, East, 32),
+				NeighborInt(1702789509, South, 32), NeighborInt(1702789509, West, 32),
+			},
+		},
+		{
+			desc:    "antimeridian",
+			geohash: EncodeInt(10, 179.999, 32),
+			expected: []int64{
+				NeighborInt(EncodeInt(10, 179.999, 32), North, 32), EncodeInt(10, -179.999, 32),
+				NeighborInt(EncodeInt(10, 179.999, 32), South, 32), NeighborInt(EncodeInt(10, 179.999, 32), West, 32),
+			},
+		},
+		{
+			desc:    "north pole",
+			geohash: EncodeInt(90, 10, 32),
+			expected: []int64{
+				EncodeInt(90, 10, 32), NeighborInt(EncodeInt(90, 10, 32), East, 32),
+				NeighborInt(EncodeInt(90, 10, 32), South, 32), NeighborInt(EncodeInt(90, 10, 32), West, 32),
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		result := EdgeNeighborsInt(scenario.geohash, 32)
+		if len(scenario.expected) != len(result) {
+			t.Fatalf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected, result)
+		}
+		for index := range result {
+			if scenario.expected[index] != result[index] {
+				t.Errorf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected, result)
+				break
+			}
+		}
+	}
+}
+
+func BenchmarkNeighborsInt(b *testing.B) {
+	b.ReportAllocs()
+	var result []int64
+	for i := 0; i < b.N; i++ {
+		result = NeighborsInt(1702789509, 32)
+	}
+	_ = result
+}
+
+func BenchmarkNeighborsIntInto(b *testing.B) {
+	b.ReportAllocs()
+	result := make([]int64, 0, 9)
+	for i := 0; i < b.N; i++ {
+		result = NeighborsIntInto(1702789509, 32, result)
+	}
+	_ = result
+}
@@ -0,0 +1,278 @@
+package geohash
+
+import (
+	"fmt"
+	"strings"
+)
+
+// base32 is the standard geohash alphabet, each character encodes 5 bits.
+const base32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// bitsPerChar is the number of bits encoded by a single geohash character.
+const bitsPerChar = 5
+
+// EncodeString will encode a pair of latitude and longitude values into a standard base32 geohash string of chars
+// characters.
+//
+// The string is always exactly chars characters long, see EncodeStringPadded.
+func EncodeString(latitude float64, longitude float64, chars int) string {
+	// input validation
+	validateChars(chars)
+	validateCoordinates(latitude, longitude)
+
+	return encodeString(latitude, longitude, chars, base32)
+}
+
+// EncodeStringPadded is the same as EncodeString but makes its fixed length explicit, for keys in a sorted store where
+// every geohash must be the same length so that lexicographic range scans match the geohash ordering.
+//
+// The result is always exactly chars characters, with no leading characters dropped for coordinates whose leading
+// bits are all 0 (such as -90, -180, which gives "000...") and no characters dropped at the maximum coordinates.
+func EncodeStringPadded(latitude float64, longitude float64, chars int) string {
+	return EncodeString(latitude, longitude, chars)
+}
+
+// EncodeStringAlphabet will encode a pair of latitude and longitude values into a geohash string of chars characters
+// using a custom alphabet in place of the standard base32 one, for geohash dialects that permute or substitute it.
+//
+// The alphabet must be 32 unique ASCII characters, the character at index i encoding the 5 bits of value i. An error
+// is returned for an invalid alphabet, chars or coordinates. See DecodeStringAlphabet for the inverse.
+func EncodeStringAlphabet(latitude float64, longitude float64, chars int, alphabet string) (string, error) {
+	// input validation
+	if err := checkAlphabet(alphabet); err != nil {
+		return "", err
+	}
+	if chars <= 0 {
+		return "", fmt.Errorf("chars must be greater than 0, was %d", chars)
+	}
+	if err := checkCoordinates(latitude, longitude); err != nil {
+		return "", err
+	}
+
+	return encodeString(latitude, longitude, chars, alphabet), nil
+}
+
+// encodeString implements EncodeString with the supplied alphabet.
+func encodeString(latitude float64, longitude float64, chars int, alphabet string) string {
+	var maxLat float64 = 90.0
+	var minLat float64 = -90.0
+	var maxLng float64 = 180.0
+	var minLng float64 = -180.0
+
+	output := make([]byte, chars)
+	var bitsTotal int
+	for index := range output {
+		var value int
+		for bit := 0; bit < bitsPerChar; bit++ {
+			value *= 2
+			if bitsTotal%2 == 0 {
+				mid := (maxLng + minLng) / 2
+				if longitude > mid {
+					value++
+					minLng = mid
+				} else {
+					maxLng = mid
+				}
+			} else {
+				mid := (maxLat + minLat) / 2
+				if latitude > mid {
+					value++
+					minLat = mid
+				} else {
+					maxLat = mid
+				}
+			}
+			bitsTotal++
+		}
+		output[index] = alphabet[value]
+	}
+	return string(output)
+}
+
+// DecodeString will decode a base32 geohash string into a pair of latitude and longitude value approximations along
+// with the maximum error of the calculation, as DecodeInt does.
+func DecodeString(geohash string) (lat float64, lng float64, latErr float64, lngErr float64, err error) {
+	return DecodeStringAlphabet(geohash, base32)
+}
+
+// DecodeStringAlphabet will decode a geohash string encoded with a custom alphabet (see EncodeStringAlphabet) as
+// DecodeString does.
+func DecodeStringAlphabet(geohash string, alphabet string) (lat float64, lng float64, latErr float64, lngErr float64, err error) {
+	minLat, minLng, maxLat, maxLng, err := DecodeBboxStringAlphabet(geohash, alphabet)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+
+	lat = (minLat + maxLat) / 2
+	lng = (minLng + maxLng) / 2
+	latErr = maxLat - lat
+	lngErr = maxLng - lng
+	return
+}
+
+// DecodeBboxString will decode a base32 geohash string into the bounding box that matches it.
+func DecodeBboxString(geohash string) (minLat float64, minLng float64, maxLat float64, maxLng float64, err error) {
+	return DecodeBboxStringAlphabet(geohash, base32)
+}
+
+// DecodeBboxStringAlphabet will decode a geohash string encoded with a custom alphabet (see EncodeStringAlphabet)
+// into the bounding box that matches it.
+func DecodeBboxStringAlphabet(geohash string, alphabet string) (minLat float64, minLng float64, maxLat float64, maxLng float64, err error) {
+	if err := checkAlphabet(alphabet); err != nil {
+		return 0, 0, 0, 0, err
+	}
+	if len(geohash) == 0 {
+		return 0, 0, 0, 0, fmt.Errorf("geohash must not be empty")
+	}
+
+	maxLat = 90
+	minLat = -90
+	maxLng = 180
+	minLng = -180
+
+	var bitsTotal int
+	for index := 0; index < len(geohash); index++ {
+		value := strings.IndexByte(alphabet, geohash[index])
+		if value < 0 {
+			return 0, 0, 0, 0, fmt.Errorf("invalid geohash character %q in %q", geohash[index], geohash)
+		}
+
+		for bit := bitsPerChar - 1; bit >= 0; bit-- {
+			set := (value>>uint(bit))&0x01 == 1
+			if bitsTotal%2 == 0 {
+				mid := (maxLng + minLng) / 2
+				if set {
+					minLng = mid
+				} else {
+					maxLng = mid
+				}
+			} else {
+				mid := (maxLat + minLat) / 2
+				if set {
+					minLat = mid
+				} else {
+					maxLat = mid
+				}
+			}
+			bitsTotal++
+		}
+	}
+	return
+}
+
+// FormatHash will convert a geohash integer into a base32 geohash string with the supplied prefix (which may be empty).
+//
+// The string is ceil(bitDepth/5) characters long. When bitDepth is not a multiple of 5 the final character is padded
+// with zero bits, so the string describes the south west most sub-cell of the original.
+func FormatHash(geohash int64, bitDepth int64, prefix string) string {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	chars := int((bitDepth + bitsPerChar - 1) / bitsPerChar)
+
+	output := make([]byte, chars)
+	for index := range output {
+		// the final character may need padding with zero bits
+		shift := bitDepth - int64((index+1)*bitsPerChar)
+		var value int64
+		if shift >= 0 {
+			value = geohash >> uint64(shift)
+		} else {
+			value = geohash << uint64(-shift)
+		}
+		output[index] = base32[value&0x1f]
+	}
+	return prefix + string(output)
+}
+
+// ParseHash will convert a base32 geohash string that starts with prefix (which may be empty) into a geohash
+// integer and its bitDepth.
+//
+// The bitDepth is the deepest even bitDepth (up to MaxBitDepth64) that the characters fully describe, so an odd
+// length string loses its final longitude bit. The exception is the 11 characters FormatHash uses for MaxBitDepth,
+// which are read as MaxBitDepth rather than 54 bits. FormatHash and ParseHash round trip for exactly the bit depths
+// that ParseHash returns: 10n and 10n+4 up to 50, MaxBitDepth, 60 and MaxBitDepth64.
+func ParseHash(s string, prefix string) (int64, int64, error) {
+	if !strings.HasPrefix(s, prefix) {
+		return 0, 0, fmt.Errorf("geohash %q does not start with %q", s, prefix)
+	}
+	s = s[len(prefix):]
+	if len(s) == 0 {
+		return 0, 0, fmt.Errorf("geohash must not be empty")
+	}
+
+	bitDepth := stringBitDepth(len(s))
+
+	var geohash int64
+	var bitsTotal int64
+	for index := 0; index < len(s); index++ {
+		value := strings.IndexByte(base32, s[index])
+		if value < 0 {
+			return 0, 0, fmt.Errorf("invalid geohash character %q in %q", s[index], s)
+		}
+
+		for bit := bitsPerChar - 1; bit >= 0 && bitsTotal < bitDepth; bit-- {
+			geohash = geohash*2 + int64((value>>uint(bit))&0x01)
+			bitsTotal++
+		}
+	}
+	return geohash, bitDepth, nil
+}
+
+// stringBitDepth returns the bitDepth ParseHash reads from a geohash string of chars characters.
+func stringBitDepth(chars int) int64 {
+	bitDepth := int64(chars*bitsPerChar) &^ 1
+	switch {
+	case bitDepth > MaxBitDepth64:
+		return MaxBitDepth64
+	case bitDepth > MaxBitDepth && bitDepth < MaxBitDepth+bitsPerChar:
+		// the length of a MaxBitDepth hash, whose final bits are padding
+		return MaxBitDepth
+	}
+	return bitDepth
+}
+
+// StringSuccessor will return the smallest base32 geohash string that is greater than every string starting with
+// prefix, so that the strings with the prefix are exactly those >= prefix and < the successor.
+//
+// The base32 alphabet is in ASCII order so this is also the byte-wise ordering used by most stores. The final
+// character is advanced to the next in the alphabet, with any trailing 'z' characters dropped first, so the successor
+// of "gzz" is "h". An empty string is returned when there is no upper bound, for an empty prefix or one made only of
+// 'z'. An error is returned for a character that is not in the base32 alphabet.
+func StringSuccessor(prefix string) (string, error) {
+	for index := 0; index < len(prefix); index++ {
+		if strings.IndexByte(base32, prefix[index]) < 0 {
+			return "", fmt.Errorf("invalid geohash character %q in %q", prefix[index], prefix)
+		}
+	}
+
+	trimmed := strings.TrimRight(prefix, base32[len(base32)-1:])
+	if len(trimmed) == 0 {
+		return "", nil
+	}
+	last := strings.IndexByte(base32, trimmed[len(trimmed)-1])
+	return trimmed[:len(trimmed)-1] + string(base32[last+1]), nil
+}
+
+// validateChars will ensure the supplied number of geohash characters is valid or cause panic() otherwise.
+func validateChars(chars int) {
+	if chars <= 0 {
+		panic(fmt.Sprintf("chars must be greater than 0, was %d", chars))
+	}
+}
+
+// checkAlphabet will return an error when the supplied alphabet is not exactly 32 unique ASCII characters.
+func checkAlphabet(alphabet string) error {
+	if len(alphabet) != 1<<bitsPerChar {
+		return fmt.Errorf("alphabet must be %d characters, was %d", 1<<bitsPerChar, len(alphabet))
+	}
+	for index := 0; index < len(alphabet); index++ {
+		if alphabet[index] >= 0x80 {
+			return fmt.Errorf("alphabet must only contain ASCII characters, was %q", alphabet)
+		}
+		if strings.IndexByte(alphabet[:index], alphabet[index]) >= 0 {
+			return fmt.Errorf("alphabet must not repeat characters, %q is repeated", alphabet[index])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package geohash
+
+import (
+	"testing"
+)
+
+func TestEncodeStringBasic(t *testing.T) {
+	expected := "ww8p1r4t8"
+
+	result := EncodeString(37.8324, 112.5584, 9)
+
+	if expected != result {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
+
+func TestEncodeStringPadded(t *testing.T) {
+	scenarios := []struct {
+		desc     string
+		lat      float64
+		lng      float64
+		expected string
+	}{
+		{desc: "south west", lat: -90, lng: -180, expected: "000000000000"},
+		{desc: "origin", lat: 0, lng: 0, expected: "7zzzzzzzzzzz"},
+		{desc: "north east", lat: 90, lng: 180, expected: "zzzzzzzzzzzz"},
+		{desc: "typical", lat: 37.8324, lng: 112.5584, expected: EncodeString(37.8324, 112.5584, 12)},
+	}
+
+	for _, scenario := range scenarios {
+		for chars := 1; chars <= 12; chars++ {
+			result := EncodeStringPadded(scenario.lat, scenario.lng, chars)
+			if scenario.expected[:chars] != result {
+				t.Errorf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected[:chars], result)
+			}
+		}
+	}
+}
+
+func TestDecodeStringBasic(t *testing.T) {
+	var expectedLat float64 = 37.8324
+	var expectedLng float64 = 112.5584
+
+	resultLat, resultLng, latErr, lngErr, err := DecodeString("ww8p1r4t8")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !ApproxEqual(expectedLat, resultLat, latErr) {
+		t.Errorf("Expected %+v but was %+v", expectedLat, resultLat)
+	}
+	if !ApproxEqual(expectedLng, resultLng, lngErr) {
+		t.Errorf("Expected %+v but was %+v", expectedLng, resultLng)
+	}
+
+	if _, _, _, _, err := DecodeString("ww8pa"); err == nil {
+		t.Errorf("Expected an error for an invalid character")
+	}
+	if _, _, _, _, err := DecodeString(""); err == nil {
+		t.Errorf("Expected an error for an empty geohash")
+	}
+}
+
+func TestEncodeStringAlphabet(t *testing.T) {
+	reversed := "zyxwvutsrqpnmkjhgfedcb9876543210"
+	expected := "33rby8v6r"
+
+	result, err := EncodeStringAlphabet(37.8324, 112.5584, 9, reversed)
+	if err != nil || expected != result {
+		t.Errorf("Expected %+v but was %+v (%v)", expected, result, err)
+	}
+
+	// the standard alphabet is the same as EncodeString
+	result, err = EncodeStringAlphabet(37.8324, 112.5584, 9, base32)
+	if err != nil || EncodeString(37.8324, 112.5584, 9) != result {
+		t.Errorf("Expected %+v but was %+v (%v)", EncodeString(37.8324, 112.5584, 9), result, err)
+	}
+
+	lat, lng, latErr, lngErr, err := DecodeStringAlphabet(expected, reversed)
+	expectedLat, expectedLng, expectedLatErr, expectedLngErr, _ := DecodeString("ww8p1r4t8")
+	if err != nil || lat != expectedLat || lng != expectedLng || latErr != expectedLatErr || lngErr != expectedLngErr {
+		t.Errorf("Expected %+v,%+v but was %+v,%+v (%v)", expectedLat, expectedLng, lat, lng, err)
+	}
+
+	if _, _, _, _, err := DecodeStringAlphabet("ww8pa", reversed); err == nil {
+		t.Errorf("Expected an error for an invalid character")
+	}
+}
+
+func TestEncodeStringAlphabetInvalid(t *testing.T) {
+	scenarios := []struct {
+		desc     string
+		alphabet string
+		chars    int
+	}{
+		{desc: "too short", alphabet: base32[1:], chars: 9},
+		{desc: "too long", alphabet: base32 + "a", chars: 9},
+		{desc: "repeated", alphabet: "0" + base32[1:31] + "0", chars: 9},
+		{desc: "not ASCII", alphabet: base32[:30] + "é", chars: 9},
+		{desc: "no chars", alphabet: base32, chars: 0},
+	}
+
+	for _, scenario := range scenarios {
+		if _, err := EncodeStringAlphabet(37.8324, 112.5584, scenario.chars, scenario.alphabet); err == nil {
+			t.Errorf("%s: Expected an error", scenario.desc)
+		}
+	}
+
+	if _, _, _, _, err := DecodeStringAlphabet("ww8p1r4t8", base32[1:]); err == nil {
+		t.Errorf("Expected an error for an invalid alphabet")
+	}
+}
+
+func TestFormatHash(t *testing.T) {
+	geohash := EncodeInt(37.8324, 112.5584, 40)
+
+	expected := "gh:ww8p1r4t"
+	result := FormatHash(geohash, 40, "gh:")
+	if expected != result {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+
+	expected = "ww8p1r4t"
+	result = FormatHash(geohash, 40, "")
+	if expected != result {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+
+	// padded to whole characters
+	expected = EncodeString(37.8324, 112.5584, 11)[:10]
+	result = FormatHash(EncodeInt(37.8324, 112.5584, MaxBitDepth), MaxBitDepth, "")[:10]
+	if expected != result {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
+
+func TestParseHash(t *testing.T) {
+	geohash, bitDepth, err := ParseHash("gh:ww8p1r4t", "gh:")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if expected := EncodeInt(37.8324, 112.5584, 40); geohash != expected || bitDepth != 40 {
+		t.Errorf("Expected %+v,%+v but was %+v,%+v", expected, 40, geohash, bitDepth)
+	}
+
+	// odd length strings use the deepest even bit depth
+	geohash, bitDepth, err = ParseHash("ww8p1r4", "")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if expected := EncodeInt(37.8324, 112.5584, 34); geohash != expected || bitDepth != 34 {
+		t.Errorf("Expected %+v,%+v but was %+v,%+v", expected, 34, geohash, bitDepth)
+	}
+
+	invalid := [][2]string{{"ww8p1r4", "gh:"}, {"gh:", "gh:"}, {"gh:ww8pa", "gh:"}}
+	for _, input := range invalid {
+		if _, _, err := ParseHash(input[0], input[1]); err == nil {
+			t.Errorf("Expected an error for %+v", input)
+		}
+	}
+}
+
+func TestStringSuccessor(t *testing.T) {
+	scenarios := []struct {
+		desc     string
+		prefix   string
+		expected string
+	}{
+		{desc: "simple", prefix: "ww8p", expected: "ww8q"},
+		{desc: "digit to letter", prefix: "u9", expected: "ub"},
+		{desc: "skipped letter", prefix: "h", expected: "j"},
+		{desc: "carry", prefix: "gzz", expected: "h"},
+		{desc: "partial carry", prefix: "g0z", expected: "g1"},
+		{desc: "all z", prefix: "zzz", expected: ""},
+		{desc: "empty", prefix: "", expected: ""},
+	}
+
+	for _, scenario := range scenarios {
+		result, err := StringSuccessor(scenario.prefix)
+		if err != nil || scenario.expected != result {
+			t.Errorf("%s: Expected %+v but was %+v (%v)", scenario.desc, scenario.expected, result, err)
+		}
+	}
+
+	// every string with the prefix sorts before the successor
+	successor, _ := StringSuccessor("ww8p")
+	for _, geohash := range []string{"ww8p", "ww8p0", "ww8pzzzz", "ww8pz"} {
+		if !(geohash >= "ww8p" && geohash < successor) {
+			t.Errorf("Expected %q to be within %q and %q", geohash, "ww8p", successor)
+		}
+	}
+
+	if _, err := StringSuccessor("ww8a"); err == nil {
+		t.Errorf("Expected an error for an invalid character")
+	}
+}
+
+func TestFormatParseHashRoundTrip(t *testing.T) {
+	for _, bitDepth := range []int64{10, 20, 30, 40, 50, MaxBitDepth, 60, MaxBitDepth64} {
+		expected := EncodeInt(-33.8688, 151.2093, bitDepth)
+		geohash, resultDepth, err := ParseHash(FormatHash(expected, bitDepth, "gh:"), "gh:")
+		if err != nil || expected != geohash || bitDepth != resultDepth {
+			t.Errorf("Expected %+v,%+v but was %+v,%+v (%v)", expected, bitDepth, geohash, resultDepth, err)
+		}
+	}
+}
+
+func TestFormatHashExtendedBitDepth(t *testing.T) {
+	expected := EncodeString(37.8324, 112.5584, 13)[:12]
+	result := FormatHash(EncodeInt(37.8324, 112.5584, MaxBitDepth64), MaxBitDepth64, "")
+	if len(result) != 13 || expected != result[:12] {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
+
+func TestParseHashExtendedBitDepth(t *testing.T) {
+	expected := EncodeInt(37.8324, 112.5584, 60)
+	formatted := FormatHash(expected, 60, "")
+	if formatted != "ww8p1r4t8yd0" {
+		t.Errorf("Expected %+v but was %+v", "ww8p1r4t8yd0", formatted)
+	}
+
+	geohash, bitDepth, err := ParseHash(formatted, "")
+	if err != nil || expected != geohash || bitDepth != 60 {
+		t.Errorf("Expected %+v,%+v but was %+v,%+v (%v)", expected, 60, geohash, bitDepth, err)
+	}
+
+	// longer strings are truncated to MaxBitDepth64
+	geohash, bitDepth, err = ParseHash(EncodeString(37.8324, 112.5584, 15), "")
+	if expected := EncodeInt(37.8324, 112.5584, MaxBitDepth64); err != nil || expected != geohash || bitDepth != MaxBitDepth64 {
+		t.Errorf("Expected %+v,%+v but was %+v,%+v (%v)", expected, MaxBitDepth64, geohash, bitDepth, err)
+	}
+}
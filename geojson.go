@@ -0,0 +1,185 @@
+package geohash
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// geoJSON is the subset of a GeoJSON object needed to find its geometry.
+type geoJSON struct {
+	Type        string            `json:"type"`
+	Coordinates json.RawMessage   `json:"coordinates"`
+	Geometry    json.RawMessage   `json:"geometry"`
+	Geometries  []json.RawMessage `json:"geometries"`
+	Features    []json.RawMessage `json:"features"`
+}
+
+// CoverGeometry will parse a GeoJSON object and call fn with each cell at bitDepth that intersects its geometry.
+//
+// Polygon, MultiPolygon, LineString, MultiLineString, Point and MultiPoint geometries are supported, as well as
+// Feature, FeatureCollection and GeometryCollection objects that contain them. Polygon holes (interior rings) are
+// excluded from the covering, apart from the cells their boundaries pass through. Polygons are treated as planar in
+// lat/lng, so per the GeoJSON specification they should be split rather than cross the antimeridian.
+//
+// Each cell is only passed to fn once. Returning false from fn stops the covering early.
+func CoverGeometry(geojson []byte, bitDepth int64, fn func(int64) bool) error {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	seen := map[int64]bool{}
+	visit := func(geohash int64) bool {
+		if seen[geohash] {
+			return true
+		}
+		seen[geohash] = true
+		return fn(geohash)
+	}
+
+	_, err := coverGeoJSON(geojson, bitDepth, visit)
+	return err
+}
+
+// coverGeoJSON covers a single GeoJSON object and returns false if the covering was stopped early.
+func coverGeoJSON(data []byte, bitDepth int64, fn func(int64) bool) (bool, error) {
+	var object geoJSON
+	if err := json.Unmarshal(data, &object); err != nil {
+		return false, fmt.Errorf("invalid GeoJSON: %s", err)
+	}
+
+	switch object.Type {
+	case "FeatureCollection":
+		for _, feature := range object.Features {
+			if more, err := coverGeoJSON(feature, bitDepth, fn); !more || err != nil {
+				return more, err
+			}
+		}
+		return true, nil
+
+	case "Feature":
+		if len(object.Geometry) == 0 || string(object.Geometry) == "null" {
+			return true, nil
+		}
+		return coverGeoJSON(object.Geometry, bitDepth, fn)
+
+	case "GeometryCollection":
+		for _, geometry := range object.Geometries {
+			if more, err := coverGeoJSON(geometry, bitDepth, fn); !more || err != nil {
+				return more, err
+			}
+		}
+		return true, nil
+
+	case "Point":
+		var position []float64
+		if err := json.Unmarshal(object.Coordinates, &position); err != nil {
+			return false, fmt.Errorf("invalid %s coordinates: %s", object.Type, err)
+		}
+		points, err := toPoints([][]float64{position})
+		if err != nil {
+			return false, err
+		}
+		return fn(EncodeInt(points[0].Lat, points[0].Lng, bitDepth)), nil
+
+	case "MultiPoint", "LineString":
+		var positions [][]float64
+		if err := json.Unmarshal(object.Coordinates, &positions); err != nil {
+			return false, fmt.Errorf("invalid %s coordinates: %s", object.Type, err)
+		}
+		points, err := toPoints(positions)
+		if err != nil {
+			return false, err
+		}
+		if object.Type == "LineString" {
+			return coverLine(points, bitDepth, fn), nil
+		}
+		for _, point := range points {
+			if !fn(EncodeInt(point.Lat, point.Lng, bitDepth)) {
+				return false, nil
+			}
+		}
+		return true, nil
+
+	case "MultiLineString", "Polygon":
+		var lines [][][]float64
+		if err := json.Unmarshal(object.Coordinates, &lines); err != nil {
+			return false, fmt.Errorf("invalid %s coordinates: %s", object.Type, err)
+		}
+		rings, err := toRings(lines)
+		if err != nil {
+			return false, err
+		}
+		if object.Type == "Polygon" {
+			return coverRings(rings, bitDepth, fn), nil
+		}
+		for _, line := range rings {
+			if !coverLine(line, bitDepth, fn) {
+				return false, nil
+			}
+		}
+		return true, nil
+
+	case "MultiPolygon":
+		var polygons [][][][]float64
+		if err := json.Unmarshal(object.Coordinates, &polygons); err != nil {
+			return false, fmt.Errorf("invalid %s coordinates: %s", object.Type, err)
+		}
+		for _, polygon := range polygons {
+			rings, err := toRings(polygon)
+			if err != nil {
+				return false, err
+			}
+			if !coverRings(rings, bitDepth, fn) {
+				return false, nil
+			}
+		}
+		return true, nil
+
+	default:
+		return false, fmt.Errorf("unsupported GeoJSON type %q", object.Type)
+	}
+}
+
+// coverLine calls fn with each cell along a line of points and returns false if fn did.
+func coverLine(points []Point, bitDepth int64, fn func(int64) bool) bool {
+	if len(points) == 1 {
+		return fn(EncodeInt(points[0].Lat, points[0].Lng, bitDepth))
+	}
+	for index := 1; index < len(points); index++ {
+		from := points[index-1]
+		to := points[index]
+		for _, geohash := range LineHashesInt(from.Lat, from.Lng, to.Lat, to.Lng, bitDepth) {
+			if !fn(geohash) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// toRings converts a list of GeoJSON position lists into lists of points.
+func toRings(lines [][][]float64) ([][]Point, error) {
+	output := make([][]Point, 0, len(lines))
+	for _, line := range lines {
+		points, err := toPoints(line)
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, points)
+	}
+	return output, nil
+}
+
+// toPoints converts GeoJSON [longitude, latitude] positions into points.
+func toPoints(positions [][]float64) ([]Point, error) {
+	output := make([]Point, 0, len(positions))
+	for _, position := range positions {
+		if len(position) < 2 {
+			return nil, fmt.Errorf("invalid GeoJSON position %v", position)
+		}
+		if err := checkCoordinates(position[1], position[0]); err != nil {
+			return nil, err
+		}
+		output = append(output, Point{Lat: position[1], Lng: position[0]})
+	}
+	return output, nil
+}
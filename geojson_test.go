@@ -0,0 +1,107 @@
+package geohash
+
+import (
+	"testing"
+)
+
+func TestCoverGeometryPolygon(t *testing.T) {
+	geojson := []byte(`{"type":"Polygon","coordinates":[[[0.1,0.1],[33.6,0.1],[33.6,33.6],[0.1,33.6],[0.1,0.1]]]}`)
+
+	// at bit depth 10 each cell is 11.25 degrees wide and 5.625 degrees tall: 3 columns by 6 rows
+	results := collectGeometry(t, geojson, 10)
+	if len(results) != 18 {
+		t.Errorf("Expected %+v cells but was %+v", 18, len(results))
+	}
+	for _, point := range []Point{{0.2, 0.2}, {33.5, 33.5}, {16, 16}} {
+		if !results[EncodeInt(point.Lat, point.Lng, 10)] {
+			t.Errorf("Expected the cell containing %+v to be covered", point)
+		}
+	}
+}
+
+func TestCoverGeometryHole(t *testing.T) {
+	geojson := []byte(`{"type":"Polygon","coordinates":[
+		[[0.1,0.1],[33.6,0.1],[33.6,33.6],[0.1,33.6],[0.1,0.1]],
+		[[11.0,5.5],[22.7,5.5],[22.7,28.3],[11.0,28.3],[11.0,5.5]]
+	]}`)
+
+	results := collectGeometry(t, geojson, 10)
+
+	// the cells wholly inside the hole
+	for _, point := range []Point{{8, 16}, {14, 16}, {20, 16}, {25, 16}} {
+		if results[EncodeInt(point.Lat, point.Lng, 10)] {
+			t.Errorf("Expected the cell containing %+v to be excluded", point)
+		}
+	}
+	if len(results) != 14 {
+		t.Errorf("Expected %+v cells but was %+v", 14, len(results))
+	}
+}
+
+func TestCoverGeometryFeatureCollection(t *testing.T) {
+	geojson := []byte(`{"type":"FeatureCollection","features":[
+		{"type":"Feature","properties":{},"geometry":{"type":"MultiPolygon","coordinates":[
+			[[[0.1,0.1],[1,0.1],[1,1],[0.1,1],[0.1,0.1]]],
+			[[[0.2,0.2],[2,0.2],[2,2],[0.2,2],[0.2,0.2]]]
+		]}},
+		{"type":"Feature","properties":{},"geometry":{"type":"Point","coordinates":[-120,40]}},
+		{"type":"Feature","properties":{},"geometry":null}
+	]}`)
+
+	results := collectGeometry(t, geojson, 10)
+
+	// both (overlapping) polygons fall into a single cell and are only reported once
+	if len(results) != 2 {
+		t.Errorf("Expected %+v cells but was %+v", 2, len(results))
+	}
+	if !results[EncodeInt(40, -120, 10)] {
+		t.Errorf("Expected the point to be covered")
+	}
+}
+
+func TestCoverGeometryStop(t *testing.T) {
+	geojson := []byte(`{"type":"Polygon","coordinates":[[[0.1,0.1],[33.6,0.1],[33.6,33.6],[0.1,33.6],[0.1,0.1]]]}`)
+
+	count := 0
+	err := CoverGeometry(geojson, 10, func(geohash int64) bool {
+		count++
+		return count < 5
+	})
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if count != 5 {
+		t.Errorf("Expected %+v cells but was %+v", 5, count)
+	}
+}
+
+func TestCoverGeometryInvalid(t *testing.T) {
+	inputs := []string{
+		`not json`,
+		`{"type":"Circle","coordinates":[0,0]}`,
+		`{"type":"Polygon","coordinates":[[[0],[1,1],[0,1]]]}`,
+	}
+	for _, input := range inputs {
+		err := CoverGeometry([]byte(input), 10, func(int64) bool { return true })
+		if err == nil {
+			t.Errorf("Expected an error for %s", input)
+		}
+	}
+}
+
+func collectGeometry(t *testing.T, geojson []byte, bitDepth int64) map[int64]bool {
+	t.Helper()
+
+	results := map[int64]bool{}
+	err := CoverGeometry(geojson, bitDepth, func(geohash int64) bool {
+		if results[geohash] {
+			t.Errorf("Duplicate value %+v found.", geohash)
+		}
+		results[geohash] = true
+		return true
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	return results
+}
@@ -0,0 +1,69 @@
+package geohash
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Hash is a geohash integer together with the bitDepth it was encoded at.
+type Hash struct {
+	Value    int64
+	BitDepth int64
+}
+
+// MarshalText implements encoding.TextMarshaler, formatting the Hash as a base32 geohash string (see FormatHash).
+//
+// Only the bit depths that ParseHash returns (10n and 10n+4 up to 50, MaxBitDepth, 60 and MaxBitDepth64) can be
+// formatted and parsed back unchanged, so other bit depths return an error. Every Hash that UnmarshalText returns can
+// be marshaled back into the same string.
+func (h Hash) MarshalText() ([]byte, error) {
+	if err := checkHash(h); err != nil {
+		return nil, err
+	}
+	if chars := int((h.BitDepth + bitsPerChar - 1) / bitsPerChar); stringBitDepth(chars) != h.BitDepth {
+		return nil, fmt.Errorf("bitDepth %d cannot be represented as a geohash string", h.BitDepth)
+	}
+	return []byte(FormatHash(h.Value, h.BitDepth, "")), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing a base32 geohash string such as "ww8p1r4t8" into the Hash
+// (see ParseHash).
+//
+// Unlike ParseHash an error is returned rather than dropping the bits of a string that do not fit in its bitDepth,
+// such as an odd length string whose final longitude bit is set, so that MarshalText always gives back the same
+// string.
+func (h *Hash) UnmarshalText(text []byte) error {
+	geohash, bitDepth, err := ParseHash(string(text), "")
+	if err != nil {
+		return err
+	}
+	if FormatHash(geohash, bitDepth, "") != string(text) {
+		return fmt.Errorf("geohash %q has bits beyond the %d bits of a Hash", text, bitDepth)
+	}
+	*h = Hash{Value: geohash, BitDepth: bitDepth}
+	return nil
+}
+
+// sortByCurve sorts mixed bitDepth hashes in place by their position along the geohash curve, with a cell sorted
+// before any finer cells it contains.
+func sortByCurve(hashes []Hash) {
+	sort.Slice(hashes, func(i, j int) bool {
+		a := hashes[i].Value << uint64(MaxBitDepth64-hashes[i].BitDepth)
+		b := hashes[j].Value << uint64(MaxBitDepth64-hashes[j].BitDepth)
+		if a != b {
+			return a < b
+		}
+		return hashes[i].BitDepth < hashes[j].BitDepth
+	})
+}
+
+// checkHash will return an error when the Hash does not have a valid bitDepth or its value does not fit within it.
+func checkHash(hash Hash) error {
+	if err := checkBitDepth(hash.BitDepth, MaxBitDepth64); err != nil {
+		return err
+	}
+	if hash.Value < 0 || hash.Value >= int64(1)<<uint64(hash.BitDepth) {
+		return fmt.Errorf("geohash %d does not fit in bitDepth %d", hash.Value, hash.BitDepth)
+	}
+	return nil
+}
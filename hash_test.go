@@ -0,0 +1,106 @@
+package geohash
+
+import (
+	"encoding"
+	"encoding/json"
+	"flag"
+	"testing"
+)
+
+var (
+	_ encoding.TextMarshaler   = Hash{}
+	_ encoding.TextUnmarshaler = &Hash{}
+)
+
+func TestHashMarshalText(t *testing.T) {
+	hash := Hash{Value: EncodeInt(57.64911, 10.40744, 30), BitDepth: 30}
+
+	text, err := hash.MarshalText()
+	if err != nil || string(text) != "u4pruy" {
+		t.Errorf("Expected %+v but was %+v (%v)", "u4pruy", string(text), err)
+	}
+
+	var result Hash
+	if err := result.UnmarshalText(text); err != nil || result != hash {
+		t.Errorf("Expected %+v but was %+v (%v)", hash, result, err)
+	}
+
+	for _, invalid := range []Hash{{Value: 1, BitDepth: 26}, {Value: 1, BitDepth: 0}, {Value: -1, BitDepth: 30}} {
+		if _, err := invalid.MarshalText(); err == nil {
+			t.Errorf("Expected an error for %+v", invalid)
+		}
+	}
+}
+
+func TestHashMarshalTextRoundTrip(t *testing.T) {
+	for _, bitDepth := range []int64{4, 10, 14, 20, 24, 30, 34, 40, 44, 50, MaxBitDepth, 60, MaxBitDepth64} {
+		hash := Hash{Value: EncodeInt(-33.8688, 151.2093, bitDepth), BitDepth: bitDepth}
+
+		text, err := hash.MarshalText()
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+
+		var result Hash
+		if err := result.UnmarshalText(text); err != nil || result != hash {
+			t.Errorf("Expected %+v but was %+v (%v)", hash, result, err)
+		}
+	}
+
+	// every length of string unmarshals and can be marshaled back
+	for chars := 1; chars <= 13; chars++ {
+		bitDepth := stringBitDepth(chars)
+		expected := FormatHash(EncodeInt(-33.8688, 151.2093, bitDepth), bitDepth, "")
+		if len(expected) != chars {
+			t.Errorf("Expected %+v characters but was %+v", chars, expected)
+		}
+
+		var hash Hash
+		if err := hash.UnmarshalText([]byte(expected)); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		if text, err := hash.MarshalText(); err != nil || string(text) != expected {
+			t.Errorf("Expected %+v but was %+v (%v)", expected, string(text), err)
+		}
+	}
+}
+
+func TestHashUnmarshalMarshalText(t *testing.T) {
+	var hash Hash
+	if err := hash.UnmarshalText([]byte("ww8p1r4t8")); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if text, err := hash.MarshalText(); err != nil || string(text) != "ww8p1r4t8" {
+		t.Errorf("Expected %+v but was %+v (%v)", "ww8p1r4t8", string(text), err)
+	}
+}
+
+func TestHashUnmarshalTextInvalid(t *testing.T) {
+	var result Hash
+	// the final bit of "ww8p1r4t9" and the final 3 bits of the 11 characters of MaxBitDepth do not fit
+	for _, text := range []string{"", "ww8pa", "WW8P", "ww8p1r4t9", "ww8p1r4t8yd"} {
+		if err := result.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("Expected an error for %q", text)
+		}
+	}
+}
+
+func TestHashTextInterop(t *testing.T) {
+	var hash Hash
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.TextVar(&hash, "geohash", Hash{}, "")
+	if err := flags.Parse([]string{"-geohash", "ww8p1r4t8"}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	// 9 characters fully describe 44 bits
+	expected := Hash{Value: EncodeInt(37.8324, 112.5584, 44), BitDepth: 44}
+	if expected != hash {
+		t.Errorf("Expected %+v but was %+v", expected, hash)
+	}
+
+	data, err := json.Marshal([]Hash{{Value: EncodeInt(57.64911, 10.40744, 30), BitDepth: 30}})
+	if err != nil || string(data) != `["u4pruy"]` {
+		t.Errorf("Expected %+v but was %+v (%v)", `["u4pruy"]`, string(data), err)
+	}
+}
@@ -0,0 +1,235 @@
+package geohash
+
+import (
+	"math"
+)
+
+// maxLineStepDegrees is the longest piece of a great circle that will be treated as a straight line in lat/lng space.
+const maxLineStepDegrees = 1.0
+
+// LineHashesInt will return the integer geohashes of all the cells a great-circle line between two points passes through.
+//
+// The cells are returned in order from the first point to the second, include both endpoint cells, contain no
+// duplicates and each one is adjacent (possibly diagonally) to the one before it.
+//
+// Note: there is no unique great circle between two antipodal points so the path returned for them is arbitrary.
+func LineHashesInt(lat1 float64, lng1 float64, lat2 float64, lng2 float64, bitDepth int64) []int64 {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	start := toVector(lat1, lng1)
+	end := toVector(lat2, lng2)
+	angle := angleBetween(start, end)
+
+	// split the great circle into pieces that are short enough to traverse as straight lines
+	_, _, latErr, _ := DecodeInt(0, bitDepth)
+	step := math.Min(latErr*2, maxLineStepDegrees) * math.Pi / 180
+	pieces := int(math.Ceil(angle / step))
+	if pieces < 1 {
+		pieces = 1
+	}
+
+	var output []int64
+	seen := map[int64]bool{}
+	visit := func(geohash int64) {
+		if !seen[geohash] {
+			seen[geohash] = true
+			output = append(output, geohash)
+		}
+	}
+
+	fromLat, fromLng := lat1, lng1
+	for piece := 1; piece <= pieces; piece++ {
+		toLat, toLng := lat2, lng2
+		if piece < pieces {
+			toLat, toLng = slerp(start, end, angle, float64(piece)/float64(pieces)).toLatLng()
+		}
+		traverseLine(fromLat, fromLng, toLat, toLng, bitDepth, visit)
+		fromLat, fromLng = toLat, toLng
+	}
+	return output
+}
+
+// TrackCellsInt will return the geohash integers of the cells visited, in order, by a track of points such as a
+// series of GPS fixes.
+//
+// Consecutive points in the same cell are collapsed into a single entry, but a cell that is left and later returned
+// to appears again. When interpolate is true the cells that LineHashesInt passes through between consecutive points
+// are included too, so each cell is adjacent (possibly diagonally) to the one before it even when the point jumped
+// several cells between fixes.
+func TrackCellsInt(points []Point, interpolate bool, bitDepth int64) []int64 {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	var output []int64
+	visit := func(geohash int64) {
+		if len(output) == 0 || output[len(output)-1] != geohash {
+			output = append(output, geohash)
+		}
+	}
+
+	for index, point := range points {
+		if !interpolate || index == 0 {
+			visit(EncodeInt(point.Lat, point.Lng, bitDepth))
+			continue
+		}
+
+		previous := points[index-1]
+		for _, geohash := range LineHashesInt(previous.Lat, previous.Lng, point.Lat, point.Lng, bitDepth) {
+			visit(geohash)
+		}
+	}
+	return output
+}
+
+// traverseLine calls visit for each cell, in order, that the straight lat/lng line between two points passes through.
+//
+// The line takes the shorter way around the globe so it may cross the antimeridian. Cells may be visited more than
+// once when a point lies on a cell boundary.
+func traverseLine(lat1 float64, lng1 float64, lat2 float64, lng2 float64, bitDepth int64, visit func(int64)) {
+	lng1 = normalizeLng(lng1)
+	deltaLat := lat2 - lat1
+	deltaLng := normalizeLng(lng2 - lng1)
+
+	cells := int64(1) << uint64(bitDepth/2)
+	cellLat := 180 / float64(cells)
+	cellLng := 360 / float64(cells)
+
+	latIdx, lngIdx := deinterleave(EncodeInt(lat1, lng1, bitDepth), bitDepth)
+	latStep, latNext, latDelta := traverseAxis(lat1, -90, deltaLat, cellLat, latIdx)
+	lngStep, lngNext, lngDelta := traverseAxis(lng1, -180, deltaLng, cellLng, lngIdx)
+
+	visit(interleave(latIdx, lngIdx, bitDepth))
+	for latNext < 1 || lngNext < 1 {
+		next := math.Min(latNext, lngNext)
+		if latNext == next {
+			if latIdx+latStep < 0 || latIdx+latStep >= cells {
+				// the line cannot leave the globe over a pole
+				latNext = math.Inf(1)
+				continue
+			}
+			latIdx += latStep
+			latNext += latDelta
+		}
+		if lngNext == next {
+			lngIdx = (lngIdx + lngStep + cells) % cells
+			lngNext += lngDelta
+		}
+		visit(interleave(latIdx, lngIdx, bitDepth))
+	}
+
+	// ensure the end point's cell is included regardless of which side of a boundary it falls on
+	visit(EncodeInt(lat2, lng2, bitDepth))
+}
+
+// traverseAxis returns the per axis state used by traverseLine: the index step direction, the fraction of the line
+// at which the first cell boundary is crossed and the fraction of the line between subsequent boundary crossings.
+func traverseAxis(start float64, origin float64, delta float64, cellSize float64, idx int64) (step int64, next float64, deltaT float64) {
+	switch {
+	case delta > 0:
+		return 1, (origin + float64(idx+1)*cellSize - start) / delta, cellSize / delta
+	case delta < 0:
+		return -1, (origin + float64(idx)*cellSize - start) / delta, -cellSize / delta
+	default:
+		return 0, math.Inf(1), math.Inf(1)
+	}
+}
+
+// NextCellCrossingInt will return how far (in meters) a point moving along headingDegrees (clockwise from north) will
+// travel before leaving its current cell at bitDepth, and the cell it enters.
+//
+// Within the cell the path is treated as a rhumb line, which for cells of a usable size is indistinguishable from the
+// great circle. A path that leaves through a corner, crossing both a latitude and a longitude edge at once, enters the
+// diagonal neighbor. Longitude wraps around the antimeridian and a path that leaves over a pole enters the polar cell
+// on the opposite meridian. A point on the edge it is heading towards returns a distance of 0.
+func NextCellCrossingInt(lat float64, lng float64, headingDegrees float64, bitDepth int64) (distanceMeters float64, nextHash int64) {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	geohash := EncodeInt(lat, lng, bitDepth)
+	minLat, minLng, maxLat, maxLng := DecodeBboxInt(geohash, bitDepth)
+
+	// meters travelled per degree of latitude and longitude in the direction of the heading
+	heading := headingDegrees * math.Pi / 180
+	northMeters := math.Cos(heading)
+	eastMeters := math.Sin(heading)
+	latDistance, latStep := edgeDistance(lat, minLat, maxLat, northMeters, metersPerDegree)
+	lngDistance, lngStep := edgeDistance(normalizeLng(lng), minLng, maxLng, eastMeters, metersPerDegree*math.Cos(lat*math.Pi/180))
+
+	// crossing both edges within rounding error of each other means leaving through the corner
+	distanceMeters = math.Min(latDistance, lngDistance)
+	if latDistance > distanceMeters*(1+1e-9)+1e-9 {
+		latStep = 0
+	}
+	if lngDistance > distanceMeters*(1+1e-9)+1e-9 {
+		lngStep = 0
+	}
+
+	nextHash, ok := stepCell(geohash, latStep, lngStep, bitDepth)
+	if !ok {
+		// over the pole to the other side of the globe
+		cells := int64(1) << uint64(bitDepth/2)
+		nextHash, _ = stepCell(geohash, 0, cells/2, bitDepth)
+	}
+	return distanceMeters, nextHash
+}
+
+// edgeDistance returns the distance in meters along one axis from value to the edge of the range min to max that a
+// path moving at rate (in the direction of increasing value) reaches first, along with the cell step across it.
+func edgeDistance(value float64, min float64, max float64, rate float64, metersPerUnit float64) (float64, int64) {
+	// ignore the floating point noise of headings that run along an axis
+	if math.Abs(rate) < 1e-12 {
+		return math.Inf(1), 0
+	}
+	if rate > 0 {
+		return math.Max(max-value, 0) * metersPerUnit / rate, 1
+	}
+	return math.Max(value-min, 0) * metersPerUnit / -rate, -1
+}
+
+// LatLineInt will return the integer geohashes of the cells, in order from west to east, that the line of constant
+// latitude lat passes through going east from minLng to maxLng.
+//
+// When minLng is greater than maxLng the line crosses the antimeridian. There are no duplicates, even when a line
+// goes all the way around the globe.
+func LatLineInt(lat float64, minLng float64, maxLng float64, bitDepth int64) []int64 {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	latIdx, minLngIdx := deinterleave(EncodeInt(lat, minLng, bitDepth), bitDepth)
+	_, maxLngIdx := deinterleave(EncodeInt(lat, maxLng, bitDepth), bitDepth)
+
+	cells := int64(1) << uint64(bitDepth/2)
+	steps := maxLngIdx - minLngIdx
+	if BboxCrossesAntimeridian(minLng, maxLng) {
+		steps = (steps + cells) % cells
+		if steps == 0 {
+			// starting and finishing in the same cell the other way around the globe
+			steps = cells - 1
+		}
+	}
+
+	output := make([]int64, 0, steps+1)
+	for step := int64(0); step <= steps; step++ {
+		output = append(output, interleave(latIdx, (minLngIdx+step)%cells, bitDepth))
+	}
+	return output
+}
+
+// LngLineInt will return the integer geohashes of the cells, in order from south to north, that the line of constant
+// longitude lng passes through from minLat to maxLat.
+//
+// No cells are returned when minLat is greater than maxLat.
+func LngLineInt(lng float64, minLat float64, maxLat float64, bitDepth int64) []int64 {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	minLatIdx, lngIdx := deinterleave(EncodeInt(minLat, lng, bitDepth), bitDepth)
+	maxLatIdx, _ := deinterleave(EncodeInt(maxLat, lng, bitDepth), bitDepth)
+
+	var output []int64
+	for latIdx := minLatIdx; latIdx <= maxLatIdx; latIdx++ {
+		output = append(output, interleave(latIdx, lngIdx, bitDepth))
+	}
+	return output
+}
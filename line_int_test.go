@@ -0,0 +1,207 @@
+package geohash
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestLineHashesIntSinglePoint(t *testing.T) {
+	results := LineHashesInt(37.8324, 112.5584, 37.8324, 112.5584, 32)
+	expected := EncodeInt(37.8324, 112.5584, 32)
+
+	if len(results) != 1 || results[0] != expected {
+		t.Errorf("Expected %+v but was %+v", []int64{expected}, results)
+	}
+}
+
+func TestLineHashesIntAlongEquator(t *testing.T) {
+	// at bit depth 10 each cell is 11.25 degrees wide so 0.1 -> 44.9 crosses 4 cells
+	results := LineHashesInt(0.1, 0.1, 0.1, 44.9, 10)
+
+	if len(results) != 4 {
+		t.Errorf("Expected %+v cells but was %+v", 4, len(results))
+	}
+	assertLinePath(t, results, 0.1, 0.1, 0.1, 44.9, 10)
+}
+
+func TestLineHashesIntDiagonal(t *testing.T) {
+	results := LineHashesInt(30, 120, 30.01, 120.02, 40)
+	assertLinePath(t, results, 30, 120, 30.01, 120.02, 40)
+
+	results = LineHashesInt(-33.8688, 151.2093, -37.8136, 144.9631, 30)
+	assertLinePath(t, results, -33.8688, 151.2093, -37.8136, 144.9631, 30)
+}
+
+func TestLineHashesIntAntimeridian(t *testing.T) {
+	results := LineHashesInt(10, 179.9, 10, -179.9, 20)
+
+	// the short way across the antimeridian is only a couple of cells
+	if len(results) > 3 {
+		t.Errorf("Expected the short path across the antimeridian but was %+v cells", len(results))
+	}
+	assertLinePath(t, results, 10, 179.9, 10, -179.9, 20)
+}
+
+func assertLinePath(t *testing.T, results []int64, lat1 float64, lng1 float64, lat2 float64, lng2 float64, bitDepth int64) {
+	t.Helper()
+
+	if len(results) == 0 {
+		t.Fatalf("Expected a path but was empty")
+	}
+	if expected := EncodeInt(lat1, lng1, bitDepth); results[0] != expected {
+		t.Errorf("Expected start %+v but was %+v", expected, results[0])
+	}
+	if expected := EncodeInt(lat2, lng2, bitDepth); results[len(results)-1] != expected {
+		t.Errorf("Expected end %+v but was %+v", expected, results[len(results)-1])
+	}
+
+	seen := map[int64]bool{}
+	for index, resultValue := range results {
+		if seen[resultValue] {
+			t.Errorf("Duplicate value %+v found.", resultValue)
+		}
+		seen[resultValue] = true
+
+		if index == 0 {
+			continue
+		}
+		// compare cell indexes directly as NeighborsInt does not wrap around the antimeridian
+		cells := int64(1) << uint64(bitDepth/2)
+		prevLat, prevLng := deinterleave(results[index-1], bitDepth)
+		latIdx, lngIdx := deinterleave(resultValue, bitDepth)
+		deltaLng := (lngIdx - prevLng + cells) % cells
+		adjacent := (latIdx-prevLat)*(latIdx-prevLat) <= 1 && (deltaLng <= 1 || deltaLng == cells-1)
+		if !adjacent {
+			t.Errorf("Expected %+v to be adjacent to %+v", resultValue, results[index-1])
+		}
+	}
+}
+
+func TestTrackCellsInt(t *testing.T) {
+	a := EncodeInt(0.01, 0.01, 20)
+	b := NeighborInt(a, East, 20)
+	c := NeighborInt(b, East, 20)
+	_, _, latErr, lngErr := DecodeInt(0, 20)
+	center := func(geohash int64) Point {
+		lat, lng, _, _ := DecodeInt(geohash, 20)
+		return Point{Lat: lat, Lng: lng}
+	}
+	offset := func(geohash int64) Point {
+		point := center(geohash)
+		return Point{Lat: point.Lat + latErr/2, Lng: point.Lng - lngErr/2}
+	}
+
+	scenarios := []struct {
+		desc        string
+		points      []Point
+		interpolate bool
+		expected    []int64
+	}{
+		{desc: "empty", points: nil, expected: nil},
+		{desc: "repeated fixes", points: []Point{center(a), offset(a), center(b), offset(b)}, expected: []int64{a, b}},
+		{desc: "returning", points: []Point{center(a), center(b), center(a)}, expected: []int64{a, b, a}},
+		{desc: "jump", points: []Point{center(a), center(c)}, expected: []int64{a, c}},
+		{desc: "interpolated jump", points: []Point{center(a), center(c)}, interpolate: true, expected: []int64{a, b, c}},
+		{desc: "interpolated repeats", points: []Point{center(a), offset(a), center(b), center(b)}, interpolate: true, expected: []int64{a, b}},
+	}
+
+	for _, scenario := range scenarios {
+		result := TrackCellsInt(scenario.points, scenario.interpolate, 20)
+		if !reflect.DeepEqual(scenario.expected, result) {
+			t.Errorf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected, result)
+		}
+	}
+}
+
+func TestNextCellCrossingInt(t *testing.T) {
+	geohash := EncodeInt(30.1, 120.1, 30)
+	lat, lng, latErr, lngErr := DecodeInt(geohash, 30)
+	_, _, maxLat, maxLng := DecodeBboxInt(geohash, 30)
+	northMeters := latErr * metersPerDegree
+	eastMeters := lngErr * metersPerDegree * math.Cos(lat*math.Pi/180)
+
+	scenarios := []struct {
+		desc             string
+		lat              float64
+		lng              float64
+		heading          float64
+		expectedDistance float64
+		expectedHash     int64
+	}{
+		{desc: "north", lat: lat, lng: lng, heading: 0, expectedDistance: northMeters, expectedHash: StepInt(geohash, 1, 0, 30)},
+		{desc: "east", lat: lat, lng: lng, heading: 90, expectedDistance: eastMeters, expectedHash: StepInt(geohash, 0, 1, 30)},
+		{desc: "south", lat: lat, lng: lng, heading: 180, expectedDistance: northMeters, expectedHash: StepInt(geohash, -1, 0, 30)},
+		{desc: "west", lat: lat, lng: lng, heading: -90, expectedDistance: eastMeters, expectedHash: StepInt(geohash, 0, -1, 30)},
+		{
+			desc:             "corner",
+			lat:              lat,
+			lng:              lng,
+			heading:          math.Atan2(eastMeters, northMeters) * 180 / math.Pi,
+			expectedDistance: math.Hypot(northMeters, eastMeters),
+			expectedHash:     StepInt(geohash, 1, 1, 30),
+		},
+		{desc: "along the east edge", lat: lat, lng: maxLng, heading: 180, expectedDistance: northMeters, expectedHash: StepInt(geohash, -1, 0, 30)},
+		{desc: "on the north edge", lat: maxLat, lng: lng, heading: 0, expectedDistance: 0, expectedHash: StepInt(geohash, 1, 0, 30)},
+	}
+
+	for _, scenario := range scenarios {
+		distance, next := NextCellCrossingInt(scenario.lat, scenario.lng, scenario.heading, 30)
+		if math.Abs(scenario.expectedDistance-distance) > 0.01 || scenario.expectedHash != next {
+			t.Errorf("%s: Expected %+v,%+v but was %+v,%+v", scenario.desc, scenario.expectedDistance, scenario.expectedHash, distance, next)
+		}
+	}
+}
+
+func TestNextCellCrossingIntWraps(t *testing.T) {
+	_, next := NextCellCrossingInt(10.1, 179.9999, 90, 30)
+	if expected := EncodeInt(10.1, -179.9999, 30); expected != next {
+		t.Errorf("Expected %+v but was %+v", expected, next)
+	}
+
+	_, next = NextCellCrossingInt(89.9999, 10.1, 0, 30)
+	if expected := EncodeInt(89.9999, -169.9, 30); expected != next {
+		t.Errorf("Expected %+v but was %+v", expected, next)
+	}
+}
+
+func TestLatLineInt(t *testing.T) {
+	results := LatLineInt(30.1, 120.01, 120.1, 30)
+	if expected := LineHashesInt(30.1, 120.01, 30.1, 120.1, 30); len(expected) != len(results) {
+		t.Fatalf("Expected %+v but was %+v", expected, results)
+	}
+	for index, geohash := range results {
+		if expected := StepInt(results[0], 0, index, 30); expected != geohash {
+			t.Errorf("Expected %+v but was %+v", expected, geohash)
+		}
+	}
+
+	// across the antimeridian
+	results = LatLineInt(30.1, 179.98, -179.98, 30)
+	expected := []int64{EncodeInt(30.1, 179.98, 30), EncodeInt(30.1, 179.999, 30), EncodeInt(30.1, -179.999, 30), EncodeInt(30.1, -179.98, 30)}
+	if !reflect.DeepEqual(expected, results) {
+		t.Errorf("Expected %+v but was %+v", expected, results)
+	}
+
+	// all the way around
+	results = LatLineInt(30.1, 10.002, 10.001, 8)
+	if len(results) != 16 || len(NormalizeHashes(append([]int64{}, results...))) != 16 {
+		t.Errorf("Expected all 16 cells but was %+v", results)
+	}
+}
+
+func TestLngLineInt(t *testing.T) {
+	results := LngLineInt(120.1, 30.01, 30.1, 30)
+	if expected := LineHashesInt(30.01, 120.1, 30.1, 120.1, 30); len(expected) != len(results) {
+		t.Fatalf("Expected %+v but was %+v", expected, results)
+	}
+	for index, geohash := range results {
+		if expected := StepInt(results[0], index, 0, 30); expected != geohash {
+			t.Errorf("Expected %+v but was %+v", expected, geohash)
+		}
+	}
+
+	if results := LngLineInt(120.1, 30.1, 30.01, 30); len(results) != 0 {
+		t.Errorf("Expected no cells but was %+v", results)
+	}
+}
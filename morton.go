@@ -0,0 +1,39 @@
+package geohash
+
+// Morton2D will interleave the bits of x and y into a 64 bit Morton (Z-order) code, the primitive beneath the geohash
+// integer encoding.
+//
+// Bit i of x is placed at bit 2i of the code and bit i of y at bit 2i+1, so x occupies the even bit positions (from
+// the least significant bit) and y the odd ones. A geohash integer uses the same layout with the latitude cell index
+// as x and the longitude cell index as y, which is why its most significant bit is a longitude bit, so for the cell
+// indexes of a geohash at bitDepth the Morton code equals the geohash integer. See DecodeMorton2D for the inverse.
+func Morton2D(x uint32, y uint32) uint64 {
+	return spreadBits(x) | spreadBits(y)<<1
+}
+
+// DecodeMorton2D will split a Morton code built by Morton2D back into its x (even bits) and y (odd bits) values.
+func DecodeMorton2D(code uint64) (x uint32, y uint32) {
+	return compactBits(code), compactBits(code >> 1)
+}
+
+// spreadBits moves bit i of value to bit 2i, leaving the odd bits zero.
+func spreadBits(value uint32) uint64 {
+	output := uint64(value)
+	output = (output | output<<16) & 0x0000ffff0000ffff
+	output = (output | output<<8) & 0x00ff00ff00ff00ff
+	output = (output | output<<4) & 0x0f0f0f0f0f0f0f0f
+	output = (output | output<<2) & 0x3333333333333333
+	output = (output | output<<1) & 0x5555555555555555
+	return output
+}
+
+// compactBits is the inverse of spreadBits, moving bit 2i of value to bit i and discarding the odd bits.
+func compactBits(value uint64) uint32 {
+	output := value & 0x5555555555555555
+	output = (output | output>>1) & 0x3333333333333333
+	output = (output | output>>2) & 0x0f0f0f0f0f0f0f0f
+	output = (output | output>>4) & 0x00ff00ff00ff00ff
+	output = (output | output>>8) & 0x0000ffff0000ffff
+	output = (output | output>>16) & 0x00000000ffffffff
+	return uint32(output)
+}
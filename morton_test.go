@@ -0,0 +1,49 @@
+package geohash
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMorton2D(t *testing.T) {
+	scenarios := []struct {
+		desc     string
+		x        uint32
+		y        uint32
+		expected uint64
+	}{
+		{desc: "zero", x: 0, y: 0, expected: 0},
+		{desc: "x", x: 1, y: 0, expected: 1},
+		{desc: "y", x: 0, y: 1, expected: 2},
+		{desc: "pattern", x: 0x0f, y: 0x03, expected: 0x5f},
+		{desc: "max", x: 0xffffffff, y: 0xffffffff, expected: 0xffffffffffffffff},
+		{desc: "max x", x: 0xffffffff, y: 0, expected: 0x5555555555555555},
+		{desc: "max y", x: 0, y: 0xffffffff, expected: 0xaaaaaaaaaaaaaaaa},
+	}
+
+	for _, scenario := range scenarios {
+		result := Morton2D(scenario.x, scenario.y)
+		if scenario.expected != result {
+			t.Errorf("%s: Expected %#x but was %#x", scenario.desc, scenario.expected, result)
+		}
+
+		x, y := DecodeMorton2D(result)
+		if scenario.x != x || scenario.y != y {
+			t.Errorf("%s: Expected %+v,%+v but was %+v,%+v", scenario.desc, scenario.x, scenario.y, x, y)
+		}
+	}
+}
+
+func TestMorton2DMatchesGeohash(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		lat := random.Float64()*180 - 90
+		lng := random.Float64()*360 - 180
+
+		geohash := EncodeInt(lat, lng, MaxBitDepth64)
+		latIdx, lngIdx := deinterleave(geohash, MaxBitDepth64)
+		if result := Morton2D(uint32(latIdx), uint32(lngIdx)); uint64(geohash) != result {
+			t.Errorf("Expected %+v but was %+v", geohash, result)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package geohash
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Order defines the sequence in which cells are returned by BboxesIntOrdered.
+type Order int
+
+const (
+	// RowMajor returns cells row by row from the south west corner, as BboxesInt does.
+	RowMajor Order = iota
+
+	// Hilbert returns cells in the order they are visited by a Hilbert curve over the whole globe, so consecutive
+	// cells are usually spatially close.
+	Hilbert
+
+	// ZOrder returns cells in ascending geohash integer order, the Z-order (Morton) curve the geohash encoding follows.
+	ZOrder
+)
+
+// BboxesIntOrdered will return the same hash integers as BboxesInt but in the requested order.
+func BboxesIntOrdered(minLat float64, minLon float64, maxLat float64, maxLon float64, bitDepth int64, order Order) []int64 {
+	output := BboxesInt(minLat, minLon, maxLat, maxLon, bitDepth)
+
+	switch order {
+	case RowMajor:
+		// already row-major
+
+	case Hilbert:
+		indexes := make(map[int64]int64, len(output))
+		for _, geohash := range output {
+			indexes[geohash] = hilbertIndex(geohash, bitDepth)
+		}
+		sort.SliceStable(output, func(i, j int) bool {
+			return indexes[output[i]] < indexes[output[j]]
+		})
+
+	case ZOrder:
+		sortHashes(output)
+
+	default:
+		panic(fmt.Sprintf("unknown order %d", order))
+	}
+	return output
+}
+
+// CurvePosition will return the position, from 0 up to but not including 1, of a geohash integer's cell along the
+// whole globe ordered by order, such as for mapping cells onto a 1D color gradient.
+//
+// For ZOrder this is geohash / 2^bitDepth and for Hilbert the cell's distance along the Hilbert curve divided by the
+// same. Both curves are hierarchical: the positions of all of a cell's descendants, at any finer bitDepth, are within
+// the cell's position and the next cell's, so coarser cells sort before the cells they contain and the two orders
+// are monotonic across bit depths. RowMajor (row by row from the south west) is not. Above 53 bits the position is
+// rounded to the nearest float64.
+func CurvePosition(geohash int64, bitDepth int64, order Order) float64 {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	var index int64
+	switch order {
+	case RowMajor:
+		latIdx, lngIdx := deinterleave(geohash, bitDepth)
+		index = latIdx<<uint64(bitDepth/2) | lngIdx
+
+	case Hilbert:
+		index = hilbertIndex(geohash, bitDepth)
+
+	case ZOrder:
+		index = geohash
+
+	default:
+		panic(fmt.Sprintf("unknown order %d", order))
+	}
+	return math.Ldexp(float64(index), -int(bitDepth))
+}
+
+// hilbertIndex returns the distance along a Hilbert curve covering the whole globe of a cell at bitDepth.
+func hilbertIndex(geohash int64, bitDepth int64) int64 {
+	y, x := deinterleave(geohash, bitDepth)
+	n := int64(1) << uint64(bitDepth/2)
+
+	var d int64
+	for s := n / 2; s > 0; s /= 2 {
+		var rx, ry int64
+		if x&s > 0 {
+			rx = 1
+		}
+		if y&s > 0 {
+			ry = 1
+		}
+		d += s * s * ((3 * rx) ^ ry)
+
+		// rotate the quadrant so the curve continues in the right direction
+		if ry == 0 {
+			if rx == 1 {
+				x = n - 1 - x
+				y = n - 1 - y
+			}
+			x, y = y, x
+		}
+	}
+	return d
+}
@@ -0,0 +1,90 @@
+package geohash
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBboxesIntOrderedRowMajor(t *testing.T) {
+	expected := BboxesInt(30, 120, 30.01, 120.01, 30)
+	result := BboxesIntOrdered(30, 120, 30.01, 120.01, 30, RowMajor)
+	if !reflect.DeepEqual(expected, result) {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
+
+func TestBboxesIntOrderedHilbert(t *testing.T) {
+	expected := BboxesInt(30, 120, 30.01, 120.01, 30)
+	result := BboxesIntOrdered(30, 120, 30.01, 120.01, 30, Hilbert)
+
+	added, removed := DiffCoverings(expected, result)
+	if len(result) != len(expected) || len(added) != 0 || len(removed) != 0 {
+		t.Errorf("Expected the same cells as BboxesInt but was %+v", result)
+	}
+}
+
+func TestBboxesIntOrderedHilbertAdjacent(t *testing.T) {
+	// the whole globe at bit depth 4 is a 4x4 grid and the curve must step between sharing edge neighbors
+	result := BboxesIntOrdered(-90, -180, 90, 180, 4, Hilbert)
+	if len(result) != 16 {
+		t.Fatalf("Expected %+v cells but was %+v", 16, len(result))
+	}
+	for index := 1; index < len(result); index++ {
+		bearing, ok := BearingBetweenInt(result[index-1], result[index], 4)
+		if !ok || (bearing != North && bearing != East && bearing != South && bearing != West) {
+			t.Errorf("Expected %+v to share an edge with %+v", result[index], result[index-1])
+		}
+	}
+	if result[0] != EncodeInt(-89, -179, 4) {
+		t.Errorf("Expected the curve to start in the south west cell but was %+v", result[0])
+	}
+}
+
+func TestBboxesIntOrderedZOrder(t *testing.T) {
+	expected := BboxesInt(30, 120, 30.01, 120.01, 30)
+	sortHashes(expected)
+	result := BboxesIntOrdered(30, 120, 30.01, 120.01, 30, ZOrder)
+	if !reflect.DeepEqual(expected, result) {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
+
+func TestCurvePosition(t *testing.T) {
+	scenarios := []struct {
+		desc     string
+		geohash  int64
+		bitDepth int64
+		order    Order
+		expected float64
+	}{
+		{desc: "z-order first", geohash: 0, bitDepth: 2, order: ZOrder, expected: 0},
+		{desc: "z-order last", geohash: 3, bitDepth: 2, order: ZOrder, expected: 0.75},
+		{desc: "z-order middle", geohash: 1 << 19, bitDepth: 20, order: ZOrder, expected: 0.5},
+		// the Hilbert curve starts in the south west and ends in the south east
+		{desc: "hilbert first", geohash: EncodeInt(-89, -179, 4), bitDepth: 4, order: Hilbert, expected: 0},
+		{desc: "hilbert last", geohash: EncodeInt(-89, 179, 4), bitDepth: 4, order: Hilbert, expected: 15.0 / 16},
+		{desc: "row major", geohash: EncodeInt(-30, 100, 4), bitDepth: 4, order: RowMajor, expected: 7.0 / 16},
+	}
+
+	for _, scenario := range scenarios {
+		result := CurvePosition(scenario.geohash, scenario.bitDepth, scenario.order)
+		if scenario.expected != result {
+			t.Errorf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected, result)
+		}
+	}
+}
+
+func TestCurvePositionHierarchical(t *testing.T) {
+	geohash := EncodeInt(37.8324, 112.5584, 20)
+	for _, order := range []Order{ZOrder, Hilbert} {
+		start := CurvePosition(geohash, 20, order)
+		end := start + 1.0/(1<<20)
+		for _, child := range ChildrenInt(geohash, 20) {
+			for _, grandchild := range ChildrenInt(child, 22) {
+				if result := CurvePosition(grandchild, 24, order); result < start || result >= end {
+					t.Errorf("%d: Expected %+v to be within %+v and %+v", order, result, start, end)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package geohash
+
+import (
+	"math"
+)
+
+// EncodeIntWithOrigin will encode a pair of latitude and longitude values into a geohash integer on a grid that has
+// been shifted so that originLat, originLng falls exactly on a cell corner.
+//
+// The grid is moved by less than one cell, so hashes remain close to their standard values, but they are NOT
+// interchangeable with those of EncodeInt or any other geohash system. Only use them with the matching
+// DecodeIntWithOrigin and the same origin. Cells along the poles absorb the shift and are slightly taller or shorter.
+func EncodeIntWithOrigin(lat float64, lng float64, originLat float64, originLng float64, bitDepth int64) int64 {
+	// input validation
+	validateBitDepth(bitDepth)
+	validateCoordinates(originLat, originLng)
+
+	latOffset, lngOffset := originOffset(originLat, originLng, bitDepth)
+	return EncodeInt(lat-latOffset, normalizeLng(lng-lngOffset), bitDepth)
+}
+
+// DecodeIntWithOrigin will decode a geohash integer produced by EncodeIntWithOrigin into the center of its cell along
+// with the maximum error of the calculation.
+func DecodeIntWithOrigin(geohash int64, originLat float64, originLng float64, bitDepth int64) (lat float64, lng float64, latErr float64, lngErr float64) {
+	// input validation
+	validateBitDepth(bitDepth)
+	validateCoordinates(originLat, originLng)
+
+	latOffset, lngOffset := originOffset(originLat, originLng, bitDepth)
+	lat, lng, latErr, lngErr = DecodeInt(geohash, bitDepth)
+	return lat + latOffset, normalizeLng(lng + lngOffset), latErr, lngErr
+}
+
+// originOffset returns how far the origin is north and east of the nearest standard cell corner south west of it.
+func originOffset(originLat float64, originLng float64, bitDepth int64) (latOffset float64, lngOffset float64) {
+	cells := float64(int64(1) << uint64(bitDepth/2))
+	latOffset = math.Mod(originLat+90, 180/cells)
+	lngOffset = math.Mod(normalizeLng(originLng)+180, 360/cells)
+	return
+}
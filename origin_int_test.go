@@ -0,0 +1,42 @@
+package geohash
+
+import (
+	"testing"
+)
+
+func TestEncodeIntWithOrigin(t *testing.T) {
+	originLat, originLng := 37.8324, 112.5584
+
+	// points just north east and south west of the origin must be in different cells
+	northEast := EncodeIntWithOrigin(originLat+0.00001, originLng+0.00001, originLat, originLng, 20)
+	southWest := EncodeIntWithOrigin(originLat-0.00001, originLng-0.00001, originLat, originLng, 20)
+	if northEast == southWest {
+		t.Errorf("Expected the origin to be on a cell boundary")
+	}
+
+	// the origin is the south west corner of the north east cell
+	lat, lng, latErr, lngErr := DecodeIntWithOrigin(northEast, originLat, originLng, 20)
+	if !ApproxEqual(originLat, lat-latErr, 1e-9) || !ApproxEqual(originLng, lng-lngErr, 1e-9) {
+		t.Errorf("Expected corner %+v,%+v but was %+v,%+v", originLat, originLng, lat-latErr, lng-lngErr)
+	}
+}
+
+func TestEncodeIntWithOriginRoundTrip(t *testing.T) {
+	points := []Point{{37.8324, 112.5584}, {-33.8688, 151.2093}, {10, 179.99}, {-10, -179.99}}
+	for _, point := range points {
+		geohash := EncodeIntWithOrigin(point.Lat, point.Lng, 1.2345, 178.9, 30)
+		lat, lng, latErr, lngErr := DecodeIntWithOrigin(geohash, 1.2345, 178.9, 30)
+		if !ApproxEqual(point.Lat, lat, latErr) || !ApproxEqual(point.Lng, lng, lngErr) {
+			t.Errorf("Expected %+v,%+v but was %+v,%+v", point.Lat, point.Lng, lat, lng)
+		}
+	}
+}
+
+func TestEncodeIntWithOriginAligned(t *testing.T) {
+	// an origin already on a cell corner leaves the grid unchanged
+	expected := EncodeInt(37.8324, 112.5584, 20)
+	result := EncodeIntWithOrigin(37.8324, 112.5584, 0, 0, 20)
+	if expected != result {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
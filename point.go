@@ -0,0 +1,106 @@
+package geohash
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Point is a latitude and longitude pair in degrees.
+type Point struct {
+	Lat float64
+	Lng float64
+}
+
+// CellCorners will return the four corners of a geohash integer's cell in counter-clockwise order starting from the
+// south west corner: SW, SE, NE, NW.
+func CellCorners(geohash int64, bitDepth int64) [4]Point {
+	minLat, minLng, maxLat, maxLng := DecodeBboxInt(geohash, bitDepth)
+	return [4]Point{
+		{Lat: minLat, Lng: minLng},
+		{Lat: minLat, Lng: maxLng},
+		{Lat: maxLat, Lng: maxLng},
+		{Lat: maxLat, Lng: minLng},
+	}
+}
+
+// CellCenter will return the center of a geohash integer's cell, as DecodeInt does.
+func CellCenter(geohash int64, bitDepth int64) Point {
+	lat, lng, _, _ := DecodeInt(geohash, bitDepth)
+	return Point{Lat: lat, Lng: lng}
+}
+
+// PixelToHashInt will return the geohash integer at pixelDepth of the center of a pixel in an image of a tile cell.
+//
+// The tile is drawn as tileSize by tileSize pixels with the web map convention of the origin at the top left (north
+// west) corner, x increasing to the east and y increasing down to the south, so pixel (0, 0) is the most north west
+// pixel. Tile cells are twice as wide in degrees as they are tall, so the pixels are not square in degrees. Panics
+// when tileSize is not positive or the pixel is not within the tile.
+func PixelToHashInt(tile int64, tileDepth int64, pixelX int, pixelY int, tileSize int, pixelDepth int64) int64 {
+	// input validation
+	validateBitDepth(tileDepth)
+	validateBitDepth(pixelDepth)
+	if tileSize <= 0 {
+		panic(fmt.Sprintf("tileSize must be greater than 0, was %d", tileSize))
+	}
+	if pixelX < 0 || pixelX >= tileSize || pixelY < 0 || pixelY >= tileSize {
+		panic(fmt.Sprintf("pixel %d,%d is outside of a tile of %d pixels", pixelX, pixelY, tileSize))
+	}
+
+	minLat, minLng, maxLat, maxLng := DecodeBboxInt(tile, tileDepth)
+	lat := maxLat - (maxLat-minLat)*(float64(pixelY)+0.5)/float64(tileSize)
+	lng := minLng + (maxLng-minLng)*(float64(pixelX)+0.5)/float64(tileSize)
+	return EncodeInt(lat, lng, pixelDepth)
+}
+
+// ConvexHull will return the vertices of the convex hull of a set of points in counter-clockwise order, starting from
+// the western most point (the southern most of those when there are several), without repeating the first point.
+//
+// The hull is planar in lat/lng, matching the geohash grid and CoverPolygonInt, so the points should not span the
+// antimeridian. Duplicate points and points on the hull's edges are not included, so fewer than 3 distinct points,
+// or points that are all collinear, return just the distinct end points (if any).
+func ConvexHull(points []Point) []Point {
+	sorted := make([]Point, len(points))
+	copy(sorted, points)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Lng != sorted[j].Lng {
+			return sorted[i].Lng < sorted[j].Lng
+		}
+		return sorted[i].Lat < sorted[j].Lat
+	})
+
+	// remove duplicates
+	distinct := sorted[:0]
+	for index, point := range sorted {
+		if index == 0 || point != sorted[index-1] {
+			distinct = append(distinct, point)
+		}
+	}
+	if len(distinct) < 3 {
+		return distinct
+	}
+
+	// cross is positive when o, a, b turn counter-clockwise
+	cross := func(o Point, a Point, b Point) float64 {
+		return (a.Lng-o.Lng)*(b.Lat-o.Lat) - (a.Lat-o.Lat)*(b.Lng-o.Lng)
+	}
+
+	// Andrew's monotone chain, the lower hull from west to east followed by the upper hull back again
+	hull := make([]Point, 0, 2*len(distinct))
+	for _, point := range distinct {
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], point) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, point)
+	}
+	lower := len(hull) + 1
+	for index := len(distinct) - 2; index >= 0; index-- {
+		point := distinct[index]
+		for len(hull) >= lower && cross(hull[len(hull)-2], hull[len(hull)-1], point) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, point)
+	}
+
+	// the last point is the first again
+	return hull[:len(hull)-1]
+}
@@ -0,0 +1,111 @@
+package geohash
+
+import (
+	"testing"
+)
+
+func TestCellCorners(t *testing.T) {
+	// at bit depth 4 the globe is 4 by 4 cells of 45 by 90 degrees
+	geohash := EncodeInt(10, 10, 4)
+	expected := [4]Point{{0, 0}, {0, 90}, {45, 90}, {45, 0}}
+
+	result := CellCorners(geohash, 4)
+	if expected != result {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
+
+func TestCellCenter(t *testing.T) {
+	geohash := EncodeInt(10, 10, 4)
+	expected := Point{22.5, 45}
+
+	result := CellCenter(geohash, 4)
+	if expected != result {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
+
+func TestPixelToHashInt(t *testing.T) {
+	tile := EncodeInt(37.8324, 112.5584, 20)
+
+	// a 4x4 pixel image of the tile has one pixel per grand child
+	scenarios := []struct {
+		desc     string
+		pixelX   int
+		pixelY   int
+		expected int64
+	}{
+		{desc: "top left", pixelX: 0, pixelY: 0, expected: interleave(3, 0, 4) | tile<<4},
+		{desc: "top right", pixelX: 3, pixelY: 0, expected: interleave(3, 3, 4) | tile<<4},
+		{desc: "bottom left", pixelX: 0, pixelY: 3, expected: interleave(0, 0, 4) | tile<<4},
+		{desc: "bottom right", pixelX: 3, pixelY: 3, expected: interleave(0, 3, 4) | tile<<4},
+		{desc: "middle", pixelX: 1, pixelY: 2, expected: interleave(1, 1, 4) | tile<<4},
+	}
+
+	for _, scenario := range scenarios {
+		result := PixelToHashInt(tile, 20, scenario.pixelX, scenario.pixelY, 4, 24)
+		if scenario.expected != result {
+			t.Errorf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected, result)
+		}
+	}
+
+	// a pixel at a coarser depth is the tile's ancestor
+	if result := PixelToHashInt(tile, 20, 100, 200, 256, 10); result != tile>>10 {
+		t.Errorf("Expected %+v but was %+v", tile>>10, result)
+	}
+}
+
+func TestPixelToHashIntOutsideTile(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic")
+		}
+	}()
+	PixelToHashInt(EncodeInt(37.8324, 112.5584, 20), 20, 256, 0, 256, 30)
+}
+
+func TestConvexHull(t *testing.T) {
+	scenarios := []struct {
+		desc     string
+		points   []Point
+		expected []Point
+	}{
+		{desc: "empty", points: nil, expected: []Point{}},
+		{desc: "single", points: []Point{{1, 2}}, expected: []Point{{1, 2}}},
+		{desc: "duplicates", points: []Point{{1, 2}, {1, 2}, {1, 2}}, expected: []Point{{1, 2}}},
+		{desc: "two", points: []Point{{3, 4}, {1, 2}}, expected: []Point{{1, 2}, {3, 4}}},
+		{desc: "collinear", points: []Point{{2, 2}, {0, 0}, {3, 3}, {1, 1}}, expected: []Point{{0, 0}, {3, 3}}},
+		{
+			desc:     "square with interior and edge points",
+			points:   []Point{{1, 1}, {0, 0}, {2, 2}, {0, 2}, {2, 0}, {0, 1}, {1, 2}},
+			expected: []Point{{0, 0}, {0, 2}, {2, 2}, {2, 0}},
+		},
+		{
+			desc:     "triangle",
+			points:   []Point{{Lat: 10, Lng: 20}, {Lat: -5, Lng: 10}, {Lat: 0, Lng: 30}, {Lat: 1, Lng: 21}},
+			expected: []Point{{Lat: -5, Lng: 10}, {Lat: 0, Lng: 30}, {Lat: 10, Lng: 20}},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		result := ConvexHull(scenario.points)
+		if len(scenario.expected) != len(result) {
+			t.Errorf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected, result)
+			continue
+		}
+		for index := range result {
+			if scenario.expected[index] != result[index] {
+				t.Errorf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected, result)
+				break
+			}
+		}
+	}
+}
+
+func TestConvexHullDoesNotModifyPoints(t *testing.T) {
+	points := []Point{{3, 3}, {0, 0}, {3, 0}, {1, 1}}
+	ConvexHull(points)
+	if points[0] != (Point{3, 3}) || points[3] != (Point{1, 1}) {
+		t.Errorf("Expected the points to be unchanged but was %+v", points)
+	}
+}
@@ -0,0 +1,95 @@
+package geohash
+
+import (
+	"math/bits"
+)
+
+// CommonPrefixInt will return the deepest cell that contains both a and b, along with its (even) bitDepth.
+//
+// The result is the common leading bits of a and b. A bitDepth of 0 is returned when a and b are in different
+// cells even at bitDepth 2, which means only the whole globe contains them both.
+func CommonPrefixInt(a int64, b int64, bitDepth int64) (prefix int64, prefixDepth int64) {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	differing := int64(bits.Len64(uint64(a ^ b)))
+	prefixDepth = (bitDepth - differing) &^ 1
+	if prefixDepth == 0 {
+		return 0, 0
+	}
+	return a >> uint64(bitDepth-prefixDepth), prefixDepth
+}
+
+// EnclosingHashInt will return the finest single geohash integer (and its bitDepth, up to MaxBitDepth) whose cell
+// fully contains the supplied bounding box.
+//
+// This is the common prefix of the box's south west and north east corners. A bitDepth of 0 is returned when no
+// cell smaller than the whole globe contains the box, including when the box crosses the antimeridian.
+func EnclosingHashInt(minLat float64, minLon float64, maxLat float64, maxLon float64) (int64, int64) {
+	if BboxCrossesAntimeridian(minLon, maxLon) {
+		return 0, 0
+	}
+
+	southWest := EncodeInt(minLat, minLon, MaxBitDepth)
+	northEast := EncodeInt(maxLat, maxLon, MaxBitDepth)
+	return CommonPrefixInt(southWest, northEast, MaxBitDepth)
+}
+
+// NeighborDepthInt will return the finest bitDepth (up to MaxBitDepth) at which the two points fall in the same cell or
+// in adjacent cells, including diagonally adjacent cells and cells either side of the antimeridian.
+//
+// Cells that are adjacent at one bitDepth are the same or adjacent at every coarser bitDepth, so this is the point at
+// which zooming out brings the two points together. At bitDepth 2 all cells are adjacent so the result is never less
+// than 2.
+func NeighborDepthInt(lat1 float64, lng1 float64, lat2 float64, lng2 float64) int64 {
+	a := EncodeInt(lat1, lng1, MaxBitDepth)
+	b := EncodeInt(lat2, lng2, MaxBitDepth)
+
+	// below the common prefix the points are always in the same cell
+	_, prefixDepth := CommonPrefixInt(a, b, MaxBitDepth)
+
+	bitDepth := int64(MaxBitDepth)
+	for ; bitDepth > prefixDepth && bitDepth > 2; bitDepth -= 2 {
+		shift := uint64(MaxBitDepth - bitDepth)
+		if a>>shift == b>>shift || AreAdjacentInt(a>>shift, b>>shift, bitDepth) {
+			break
+		}
+	}
+	return bitDepth
+}
+
+// SeparationDepthInt will return the coarsest bitDepth (up to MaxBitDepth) at which the two points fall in different
+// cells, which is always even and one level finer than the depth of their common prefix (see CommonPrefixInt).
+//
+// Points that are in the same cell even at MaxBitDepth, including identical points, never separate and return 0.
+func SeparationDepthInt(lat1 float64, lng1 float64, lat2 float64, lng2 float64) int64 {
+	a := EncodeInt(lat1, lng1, MaxBitDepth)
+	b := EncodeInt(lat2, lng2, MaxBitDepth)
+	if a == b {
+		return 0
+	}
+
+	_, prefixDepth := CommonPrefixInt(a, b, MaxBitDepth)
+	return prefixDepth + 2
+}
+
+// InferBitDepthInt will return the minimum (even) bitDepth whose range of geohash integers contains geohash, which is
+// the bit length of the value rounded up to an even number with a minimum of 2.
+//
+// Any finer bitDepth can contain the value too, so the result is only a lower bound and can not recover the bitDepth
+// a hash was encoded at; a stored bitDepth less than it is certainly wrong. Returns false when no bitDepth up to
+// MaxBitDepth64 can contain the value, including negative values.
+func InferBitDepthInt(geohash int64) (int64, bool) {
+	if geohash < 0 {
+		return 0, false
+	}
+
+	bitDepth := (int64(bits.Len64(uint64(geohash))) + 1) &^ 1
+	if bitDepth < 2 {
+		bitDepth = 2
+	}
+	if bitDepth > MaxBitDepth64 {
+		return 0, false
+	}
+	return bitDepth, true
+}
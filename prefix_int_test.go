@@ -0,0 +1,169 @@
+package geohash
+
+import (
+	"testing"
+)
+
+func TestCommonPrefixInt(t *testing.T) {
+	a := EncodeInt(37.8324, 112.5584, 40)
+	b := NeighborInt(a, North, 40)
+
+	prefix, prefixDepth := CommonPrefixInt(a, b, 40)
+	if prefixDepth >= 40 || prefixDepth%2 != 0 {
+		t.Errorf("Expected an even bit depth below 40 but was %+v", prefixDepth)
+	}
+	if a>>uint64(40-prefixDepth) != prefix || b>>uint64(40-prefixDepth) != prefix {
+		t.Errorf("Expected %+v to be a prefix of %+v and %+v", prefix, a, b)
+	}
+
+	prefix, prefixDepth = CommonPrefixInt(a, a, 40)
+	if prefix != a || prefixDepth != 40 {
+		t.Errorf("Expected %+v,%+v but was %+v,%+v", a, 40, prefix, prefixDepth)
+	}
+
+	// opposite sides of the globe
+	prefix, prefixDepth = CommonPrefixInt(EncodeInt(10, 10, 40), EncodeInt(-10, -10, 40), 40)
+	if prefix != 0 || prefixDepth != 0 {
+		t.Errorf("Expected %+v,%+v but was %+v,%+v", 0, 0, prefix, prefixDepth)
+	}
+}
+
+func TestEnclosingHashInt(t *testing.T) {
+	geohash, bitDepth := EnclosingHashInt(30, 120, 30.001, 120.001)
+	if bitDepth < 20 {
+		t.Errorf("Expected a fine cell but was bit depth %+v", bitDepth)
+	}
+
+	minLat, minLng, maxLat, maxLng := DecodeBboxInt(geohash, bitDepth)
+	if minLat > 30 || minLng > 120 || maxLat < 30.001 || maxLng < 120.001 {
+		t.Errorf("Expected %+v,%+v,%+v,%+v to contain the bbox", minLat, minLng, maxLat, maxLng)
+	}
+
+	// the children no longer contain both corners
+	southWest := EncodeInt(30, 120, bitDepth+2)
+	northEast := EncodeInt(30.001, 120.001, bitDepth+2)
+	if southWest == northEast {
+		t.Errorf("Expected bit depth %+v to be the finest enclosing cell", bitDepth)
+	}
+}
+
+func TestEnclosingHashIntMinimum(t *testing.T) {
+	if geohash, bitDepth := EnclosingHashInt(-1, -1, 1, 1); geohash != 0 || bitDepth != 0 {
+		t.Errorf("Expected %+v,%+v but was %+v,%+v", 0, 0, geohash, bitDepth)
+	}
+	if geohash, bitDepth := EnclosingHashInt(10, 179, 11, -179); geohash != 0 || bitDepth != 0 {
+		t.Errorf("Expected %+v,%+v but was %+v,%+v", 0, 0, geohash, bitDepth)
+	}
+}
+
+func TestNeighborDepthInt(t *testing.T) {
+	scenarios := []struct {
+		desc     string
+		distance float64
+	}{
+		{desc: "10 meters", distance: 10},
+		{desc: "1 kilometer", distance: 1000},
+		{desc: "100 kilometers", distance: 100000},
+	}
+
+	for _, scenario := range scenarios {
+		// points on the equator that only differ in longitude
+		lng := scenario.distance / metersPerDegree
+		bitDepth := NeighborDepthInt(0.01, 10.01, 0.01, 10.01+lng)
+
+		a := EncodeInt(0.01, 10.01, bitDepth)
+		b := EncodeInt(0.01, 10.01+lng, bitDepth)
+		if a != b && !AreAdjacentInt(a, b, bitDepth) {
+			t.Errorf("%s: Expected %+v and %+v to be neighbors at %+v", scenario.desc, a, b, bitDepth)
+		}
+
+		a = EncodeInt(0.01, 10.01, bitDepth+2)
+		b = EncodeInt(0.01, 10.01+lng, bitDepth+2)
+		if a == b || AreAdjacentInt(a, b, bitDepth+2) {
+			t.Errorf("%s: Expected %+v and %+v not to be neighbors at %+v", scenario.desc, a, b, bitDepth+2)
+		}
+
+		// neighboring cells are no more than twice the distance wide and no less than half of it
+		_, _, _, lngErr := DecodeInt(a, bitDepth)
+		if width := lngErr * 2 * metersPerDegree; width < scenario.distance/2 || width > scenario.distance*2 {
+			t.Errorf("%s: Expected a cell width near %+v but was %+v", scenario.desc, scenario.distance, width)
+		}
+	}
+}
+
+func TestNeighborDepthIntLimits(t *testing.T) {
+	if result := NeighborDepthInt(37.8324, 112.5584, 37.8324, 112.5584); result != MaxBitDepth {
+		t.Errorf("Expected %+v but was %+v", MaxBitDepth, result)
+	}
+
+	// either side of the antimeridian
+	if result := NeighborDepthInt(0.01, 179.9999, 0.01, -179.9999); result < 30 {
+		t.Errorf("Expected a fine bit depth but was %+v", result)
+	}
+
+	if result := NeighborDepthInt(89, 0, -89, 179); result != 2 {
+		t.Errorf("Expected %+v but was %+v", 2, result)
+	}
+}
+
+func TestSeparationDepthInt(t *testing.T) {
+	scenarios := []struct {
+		desc string
+		lat1 float64
+		lng1 float64
+		lat2 float64
+		lng2 float64
+	}{
+		{desc: "close", lat1: 37.8324, lng1: 112.5584, lat2: 37.8325, lng2: 112.5585},
+		{desc: "far", lat1: 37.8324, lng1: 112.5584, lat2: -33.8688, lng2: 151.2093},
+		{desc: "either side of the equator", lat1: 0.0001, lng1: 10, lat2: -0.0001, lng2: 10},
+		{desc: "either side of the antimeridian", lat1: 10, lng1: 179.9999, lat2: 10, lng2: -179.9999},
+	}
+
+	for _, scenario := range scenarios {
+		bitDepth := SeparationDepthInt(scenario.lat1, scenario.lng1, scenario.lat2, scenario.lng2)
+		if bitDepth < 2 || bitDepth > MaxBitDepth || bitDepth%2 != 0 {
+			t.Fatalf("%s: Expected an even bit depth but was %+v", scenario.desc, bitDepth)
+		}
+
+		if EncodeInt(scenario.lat1, scenario.lng1, bitDepth) == EncodeInt(scenario.lat2, scenario.lng2, bitDepth) {
+			t.Errorf("%s: Expected different cells at %+v", scenario.desc, bitDepth)
+		}
+		if bitDepth > 2 && EncodeInt(scenario.lat1, scenario.lng1, bitDepth-2) != EncodeInt(scenario.lat2, scenario.lng2, bitDepth-2) {
+			t.Errorf("%s: Expected the same cell at %+v", scenario.desc, bitDepth-2)
+		}
+	}
+
+	if result := SeparationDepthInt(89, 0, -89, 179); result != 2 {
+		t.Errorf("Expected %+v but was %+v", 2, result)
+	}
+	if result := SeparationDepthInt(37.8324, 112.5584, 37.8324, 112.5584); result != 0 {
+		t.Errorf("Expected %+v but was %+v", 0, result)
+	}
+}
+
+func TestInferBitDepthInt(t *testing.T) {
+	scenarios := []struct {
+		desc       string
+		geohash    int64
+		expected   int64
+		expectedOk bool
+	}{
+		{desc: "zero", geohash: 0, expected: 2, expectedOk: true},
+		{desc: "bitDepth 2", geohash: 3, expected: 2, expectedOk: true},
+		{desc: "highest bit of bitDepth 20", geohash: 1 << 19, expected: 20, expectedOk: true},
+		{desc: "lowest bit beyond bitDepth 20", geohash: 1 << 20, expected: 22, expectedOk: true},
+		{desc: "all bits of bitDepth 20", geohash: 1<<20 - 1, expected: 20, expectedOk: true},
+		{desc: "encoded", geohash: 4064984913515641, expected: MaxBitDepth, expectedOk: true},
+		{desc: "all bits of MaxBitDepth64", geohash: 1<<MaxBitDepth64 - 1, expected: MaxBitDepth64, expectedOk: true},
+		{desc: "beyond MaxBitDepth64", geohash: 1 << MaxBitDepth64, expected: 0, expectedOk: false},
+		{desc: "negative", geohash: -1, expected: 0, expectedOk: false},
+	}
+
+	for _, scenario := range scenarios {
+		result, ok := InferBitDepthInt(scenario.geohash)
+		if scenario.expected != result || scenario.expectedOk != ok {
+			t.Errorf("%s: Expected %+v,%+v but was %+v,%+v", scenario.desc, scenario.expected, scenario.expectedOk, result, ok)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package geohash
+
+import (
+	"sort"
+)
+
+// PrefixTree stores string geohashes in a trie so that geohashes with a common prefix share storage.
+//
+// It is intended for prefix matching and for clustering, where geohashes are grouped by a shorter prefix
+// depending on map zoom. A PrefixTree is not safe for concurrent modification.
+type PrefixTree struct {
+	root prefixNode
+}
+
+// prefixNode is a single character position in a PrefixTree.
+type prefixNode struct {
+	children map[byte]*prefixNode
+
+	// count is the number of times the geohash ending at this node was inserted
+	count int
+
+	// total is the number of insertions of this node and all of its descendants
+	total int
+}
+
+// NewPrefixTree will create an empty PrefixTree.
+func NewPrefixTree() *PrefixTree {
+	return &PrefixTree{}
+}
+
+// Insert adds a geohash to the tree. Inserting the same geohash more than once increases its count.
+func (p *PrefixTree) Insert(geohash string) {
+	node := &p.root
+	node.total++
+	for index := 0; index < len(geohash); index++ {
+		if node.children == nil {
+			node.children = map[byte]*prefixNode{}
+		}
+		child, found := node.children[geohash[index]]
+		if !found {
+			child = &prefixNode{}
+			node.children[geohash[index]] = child
+		}
+		node = child
+		node.total++
+	}
+	node.count++
+}
+
+// Len returns the total number of insertions into the tree.
+func (p *PrefixTree) Len() int {
+	return p.root.total
+}
+
+// PrefixMatches returns the distinct geohashes in the tree that start with prefix, in lexicographic order.
+func (p *PrefixTree) PrefixMatches(prefix string) []string {
+	node := p.find(prefix)
+	if node == nil {
+		return nil
+	}
+
+	var output []string
+	node.walk([]byte(prefix), func(geohash []byte, node *prefixNode) bool {
+		if node.count > 0 {
+			output = append(output, string(geohash))
+		}
+		return true
+	})
+	return output
+}
+
+// CollapseToLength groups the geohashes in the tree by their first n characters and returns the number of
+// insertions in each group.
+//
+// This is the usual way of clustering points on a map, with n chosen by zoom level. Geohashes shorter than n
+// are grouped under themselves.
+func (p *PrefixTree) CollapseToLength(n int) map[string]int {
+	output := map[string]int{}
+	p.root.walk(nil, func(geohash []byte, node *prefixNode) bool {
+		if len(geohash) == n {
+			output[string(geohash)] = node.total
+			return false
+		}
+		if node.count > 0 && len(geohash) > 0 {
+			output[string(geohash)] = node.count
+		}
+		return true
+	})
+	return output
+}
+
+// find returns the node for prefix or nil if there is none.
+func (p *PrefixTree) find(prefix string) *prefixNode {
+	node := &p.root
+	for index := 0; index < len(prefix); index++ {
+		node = node.children[prefix[index]]
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// walk visits this node and its descendants depth first in lexicographic order.
+//
+// Descendants of a node are skipped when fn returns false.
+func (n *prefixNode) walk(geohash []byte, fn func(geohash []byte, node *prefixNode) bool) {
+	if !fn(geohash, n) {
+		return
+	}
+
+	keys := make([]byte, 0, len(n.children))
+	for key := range n.children {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	for _, key := range keys {
+		n.children[key].walk(append(geohash, key), fn)
+	}
+}
@@ -0,0 +1,50 @@
+package geohash
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPrefixTreePrefixMatches(t *testing.T) {
+	tree := NewPrefixTree()
+	tree.Insert("ww8p1r4")
+	tree.Insert("ww8p1r5")
+	tree.Insert("ww8p1")
+	tree.Insert("ww8q")
+	tree.Insert("ww8p1r4")
+
+	expected := []string{"ww8p1", "ww8p1r4", "ww8p1r5"}
+	result := tree.PrefixMatches("ww8p")
+	if !reflect.DeepEqual(expected, result) {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+
+	if result := tree.PrefixMatches("zz"); len(result) != 0 {
+		t.Errorf("Expected no matches but was %+v", result)
+	}
+
+	if tree.Len() != 5 {
+		t.Errorf("Expected %+v but was %+v", 5, tree.Len())
+	}
+}
+
+func TestPrefixTreeCollapseToLength(t *testing.T) {
+	tree := NewPrefixTree()
+	for _, geohash := range []string{"ww8p1r4", "ww8p1r5", "ww8p2aa", "ww8q000", "wx00000", "w"} {
+		tree.Insert(geohash)
+	}
+
+	// zoomed in
+	expected := map[string]int{"ww8p": 3, "ww8q": 1, "wx00": 1, "w": 1}
+	result := tree.CollapseToLength(4)
+	if !reflect.DeepEqual(expected, result) {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+
+	// zoomed out
+	expected = map[string]int{"w": 6}
+	result = tree.CollapseToLength(1)
+	if !reflect.DeepEqual(expected, result) {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+}
@@ -0,0 +1,92 @@
+package geohash
+
+import (
+	"sort"
+)
+
+// HashRangeInt will return the inclusive [start, end] ranges of geohash integers at bitDepth that cover exactly the
+// cells BboxesInt returns for the same bbox.
+//
+// Cells that are next to each other along the geohash (Z-order) curve are merged into a single range, so a key-value
+// store can scan a few ranges rather than look up every cell. The ranges are sorted ascending and do not overlap. As
+// with BboxesInt, a bbox that crosses the antimeridian returns no ranges. See HashRangeIntMax to limit the number of
+// ranges.
+func HashRangeInt(minLat float64, minLon float64, maxLat float64, maxLon float64, bitDepth int64) [][2]int64 {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	hashSouthWest, latStep, lngStep := bboxSteps(minLat, minLon, maxLat, maxLon, bitDepth)
+	if latStep < 0 || lngStep < 0 {
+		return nil
+	}
+	minLatIdx, minLngIdx := deinterleave(hashSouthWest, bitDepth)
+	maxLatIdx, maxLngIdx := minLatIdx+int64(latStep), minLngIdx+int64(lngStep)
+
+	// walk down from the cells of bitDepth 2, emitting each cell that is entirely within the bbox as a single range
+	var output [][2]int64
+	var visit func(prefix int64, depth int64)
+	visit = func(prefix int64, depth int64) {
+		shift := uint64(bitDepth-depth) / 2
+		latIdx, lngIdx := deinterleave(prefix, depth)
+		cellMinLat, cellMaxLat := latIdx<<shift, (latIdx+1)<<shift-1
+		cellMinLng, cellMaxLng := lngIdx<<shift, (lngIdx+1)<<shift-1
+
+		if cellMaxLat < minLatIdx || cellMinLat > maxLatIdx || cellMaxLng < minLngIdx || cellMinLng > maxLngIdx {
+			return
+		}
+		if cellMinLat >= minLatIdx && cellMaxLat <= maxLatIdx && cellMinLng >= minLngIdx && cellMaxLng <= maxLngIdx {
+			start := prefix << (shift * 2)
+			end := (prefix+1)<<(shift*2) - 1
+			if len(output) > 0 && output[len(output)-1][1]+1 == start {
+				output[len(output)-1][1] = end
+			} else {
+				output = append(output, [2]int64{start, end})
+			}
+			return
+		}
+		for child := int64(0); child < 4; child++ {
+			visit(prefix<<2|child, depth+2)
+		}
+	}
+	for prefix := int64(0); prefix < 4; prefix++ {
+		visit(prefix, 2)
+	}
+	return output
+}
+
+// HashRangeIntMax will return the ranges of HashRangeInt merged into no more than maxRanges ranges.
+//
+// The smallest gaps between ranges are filled first, so the ranges over-cover the bbox by including some cells that
+// are outside of it; the fewer the ranges the more cells are included. A maxRanges of less than 1 is treated as 1.
+func HashRangeIntMax(minLat float64, minLon float64, maxLat float64, maxLon float64, bitDepth int64, maxRanges int) [][2]int64 {
+	ranges := HashRangeInt(minLat, minLon, maxLat, maxLon, bitDepth)
+	if maxRanges < 1 {
+		maxRanges = 1
+	}
+	if len(ranges) <= maxRanges {
+		return ranges
+	}
+
+	// keep the largest gaps (those after the ranges at these indexes) and fill in all of the others
+	gaps := make([]int, len(ranges)-1)
+	for index := range gaps {
+		gaps[index] = index
+	}
+	sort.SliceStable(gaps, func(i, j int) bool {
+		return ranges[gaps[i]+1][0]-ranges[gaps[i]][1] > ranges[gaps[j]+1][0]-ranges[gaps[j]][1]
+	})
+	kept := map[int]bool{}
+	for _, index := range gaps[:maxRanges-1] {
+		kept[index] = true
+	}
+
+	output := [][2]int64{ranges[0]}
+	for index := 1; index < len(ranges); index++ {
+		if kept[index-1] {
+			output = append(output, ranges[index])
+		} else {
+			output[len(output)-1][1] = ranges[index][1]
+		}
+	}
+	return output
+}
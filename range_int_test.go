@@ -0,0 +1,84 @@
+package geohash
+
+import (
+	"testing"
+)
+
+func TestHashRangeInt(t *testing.T) {
+	scenarios := []struct {
+		desc   string
+		minLat float64
+		minLng float64
+		maxLat float64
+		maxLng float64
+	}{
+		{desc: "small", minLat: 30, minLng: 120, maxLat: 30.05, maxLng: 120.07},
+		{desc: "single cell", minLat: 30.001, minLng: 120.001, maxLat: 30.001, maxLng: 120.001},
+		{desc: "southern hemisphere", minLat: -37.9, minLng: 144.8, maxLat: -37.7, maxLng: 145.1},
+	}
+
+	for _, scenario := range scenarios {
+		ranges := HashRangeInt(scenario.minLat, scenario.minLng, scenario.maxLat, scenario.maxLng, 24)
+		assertRanges(t, scenario.desc, ranges)
+
+		// the ranges contain exactly the bbox's cells
+		expected := NormalizeHashes(BboxesInt(scenario.minLat, scenario.minLng, scenario.maxLat, scenario.maxLng, 24))
+		var result []int64
+		for _, r := range ranges {
+			for geohash := r[0]; geohash <= r[1]; geohash++ {
+				result = append(result, geohash)
+			}
+		}
+		added, removed := DiffCoverings(expected, result)
+		if len(added) != 0 || len(removed) != 0 {
+			t.Errorf("%s: Expected %+v cells but added %+v and removed %+v", scenario.desc, len(expected), len(added), len(removed))
+		}
+	}
+
+	// an aligned quadrant of the globe is a single range
+	ranges := HashRangeInt(0.001, 0.001, 89.999, 179.999, 24)
+	if len(ranges) != 1 || ranges[0] != [2]int64{3 << 22, 1<<24 - 1} {
+		t.Errorf("Expected a single range but was %+v", ranges)
+	}
+
+	if ranges := HashRangeInt(0, 170, 10, -170, 24); len(ranges) != 0 {
+		t.Errorf("Expected no ranges but was %+v", ranges)
+	}
+}
+
+func TestHashRangeIntMax(t *testing.T) {
+	exact := HashRangeInt(30, 120, 30.05, 120.07, 30)
+	if len(exact) < 4 {
+		t.Fatalf("Expected several ranges but was %+v", exact)
+	}
+
+	for _, maxRanges := range []int{0, 1, 3, len(exact), len(exact) + 5} {
+		ranges := HashRangeIntMax(30, 120, 30.05, 120.07, 30, maxRanges)
+		assertRanges(t, "max", ranges)
+
+		if expected := min(max(maxRanges, 1), len(exact)); len(ranges) != expected {
+			t.Errorf("Expected %+v ranges but was %+v", expected, len(ranges))
+		}
+
+		// every exact range is still covered
+		for _, r := range exact {
+			covered := false
+			for _, merged := range ranges {
+				covered = covered || merged[0] <= r[0] && r[1] <= merged[1]
+			}
+			if !covered {
+				t.Errorf("Expected %+v to be covered by %+v", r, ranges)
+			}
+		}
+	}
+}
+
+// assertRanges checks that ranges are sorted ascending, valid and neither overlap nor touch.
+func assertRanges(t *testing.T, desc string, ranges [][2]int64) {
+	for index, r := range ranges {
+		if r[0] > r[1] || index > 0 && ranges[index-1][1]+1 >= r[0] {
+			t.Errorf("%s: Expected sorted separate ranges but was %+v", desc, ranges)
+			return
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package geohash
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// RedisMinLat is the southern most latitude that can be stored by the Redis (and ardb) GEO commands.
+	RedisMinLat float64 = -85.05112878
+
+	// RedisMaxLat is the northern most latitude that can be stored by the Redis (and ardb) GEO commands.
+	RedisMaxLat float64 = 85.05112878
+
+	// redisBitDepth is the fixed accuracy of a Redis GEO sorted set score.
+	redisBitDepth int64 = 52
+)
+
+// EncodeRedisScore will encode a pair of latitude and longitude values into the 52-bit sorted set score used by
+// the Redis GEOADD command.
+//
+// Note: Redis restricts latitude to the Web Mercator range of RedisMinLat to RedisMaxLat rather than +/-90, which means
+// the score is not the same as the result of EncodeInt at MaxBitDepth. The northern and eastern limits are in the
+// last row and column of cells rather than wrapping around. Values outside of that range cause panic().
+func EncodeRedisScore(lat float64, lng float64) float64 {
+	// input validation
+	if lat < RedisMinLat || lat > RedisMaxLat || lng < -180 || lng > 180 {
+		panic(fmt.Sprintf("coordinates must be within lat %f to %f and lng -180 to 180, was %f,%f", RedisMinLat, RedisMaxLat, lat, lng))
+	}
+
+	// Redis scales each value into its range and truncates, rather than bisecting
+	cells := int64(1) << uint64(redisBitDepth/2)
+	latIdx := int64((lat - RedisMinLat) / (RedisMaxLat - RedisMinLat) * float64(cells))
+	lngIdx := int64((lng + 180) / 360 * float64(cells))
+	if latIdx >= cells {
+		latIdx = cells - 1
+	}
+	if lngIdx >= cells {
+		lngIdx = cells - 1
+	}
+	return float64(interleave(latIdx, lngIdx, redisBitDepth))
+}
+
+// DecodeRedisScore will decode a Redis GEO sorted set score into the latitude and longitude returned by GEOPOS.
+//
+// As with GEOPOS, the result is the center of the score's cell, clamped to the valid Redis coordinate range.
+func DecodeRedisScore(score float64) (lat float64, lng float64) {
+	cells := float64(int64(1) << uint64(redisBitDepth/2))
+	latIdx, lngIdx := deinterleave(int64(score), redisBitDepth)
+
+	minLat := RedisMinLat + float64(latIdx)/cells*(RedisMaxLat-RedisMinLat)
+	maxLat := RedisMinLat + float64(latIdx+1)/cells*(RedisMaxLat-RedisMinLat)
+	minLng := -180 + float64(lngIdx)/cells*360
+	maxLng := -180 + float64(lngIdx+1)/cells*360
+
+	lat = math.Min(math.Max((minLat+maxLat)/2, RedisMinLat), RedisMaxLat)
+	lng = math.Min(math.Max((minLng+maxLng)/2, -180), 180)
+	return
+}
+
+// ConvertLatRange will convert a geohash integer encoded with latitudes scaled into fromMinLat to fromMaxLat into the
+// equivalent geohash integer with latitudes scaled into toMinLat to toMaxLat, at the same bitDepth.
+//
+// This allows hashes that use a different latitude convention, such as the Redis GEO range of RedisMinLat to
+// RedisMaxLat, to be converted to (or from) the standard -90 to 90 used by EncodeInt. Longitude is unchanged. The
+// result is the cell of the target convention that contains the center of the source cell, so the conversion is
+// lossy and a latitude outside of the target range is clamped into its southern or northern most row. Panics when
+// either range is empty or inverted.
+func ConvertLatRange(geohash int64, bitDepth int64, fromMinLat float64, fromMaxLat float64, toMinLat float64, toMaxLat float64) int64 {
+	// input validation
+	validateBitDepth(bitDepth)
+	if fromMinLat >= fromMaxLat || toMinLat >= toMaxLat {
+		panic(fmt.Sprintf("latitude ranges must have min less than max, were %f to %f and %f to %f", fromMinLat, fromMaxLat, toMinLat, toMaxLat))
+	}
+
+	cells := int64(1) << uint64(bitDepth/2)
+	latIdx, lngIdx := deinterleave(geohash, bitDepth)
+
+	lat := fromMinLat + (float64(latIdx)+0.5)/float64(cells)*(fromMaxLat-fromMinLat)
+	latIdx = int64(math.Floor((lat - toMinLat) / (toMaxLat - toMinLat) * float64(cells)))
+	if latIdx < 0 {
+		latIdx = 0
+	} else if latIdx >= cells {
+		latIdx = cells - 1
+	}
+	return interleave(latIdx, lngIdx, bitDepth)
+}
@@ -0,0 +1,106 @@
+package geohash
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEncodeRedisScore(t *testing.T) {
+	// GEOADD Sicily 13.361389 38.115556 "Palermo" 15.087269 37.502669 "Catania"
+	// ZRANGE Sicily 0 -1 WITHSCORES
+	var expected float64 = 3479099956230698
+	result := EncodeRedisScore(38.115556, 13.361389)
+	if expected != result {
+		t.Errorf("Expected %+v but was %+v", int64(expected), int64(result))
+	}
+
+	expected = 3479447370796909
+	result = EncodeRedisScore(37.502669, 15.087269)
+	if expected != result {
+		t.Errorf("Expected %+v but was %+v", int64(expected), int64(result))
+	}
+}
+
+func TestDecodeRedisScore(t *testing.T) {
+	// GEOPOS Sicily Palermo Catania
+	lat, lng := DecodeRedisScore(3479099956230698)
+	if !ApproxEqual(38.11555639549629859, lat, 1e-12) || !ApproxEqual(13.36138933897018433, lng, 1e-12) {
+		t.Errorf("Expected %+v,%+v but was %+v,%+v", 38.11555639549629859, 13.36138933897018433, lat, lng)
+	}
+
+	lat, lng = DecodeRedisScore(3479447370796909)
+	if !ApproxEqual(37.50266842333162032, lat, 1e-12) || !ApproxEqual(15.08726745843887329, lng, 1e-12) {
+		t.Errorf("Expected %+v,%+v but was %+v,%+v", 37.50266842333162032, 15.08726745843887329, lat, lng)
+	}
+}
+
+func TestEncodeRedisScoreDiffersFromEncodeInt(t *testing.T) {
+	score := EncodeRedisScore(38.115556, 13.361389)
+	if int64(score) == EncodeInt(38.115556, 13.361389, MaxBitDepth) {
+		t.Errorf("Expected the Redis latitude range to produce a different hash")
+	}
+}
+
+func TestEncodeRedisScoreLimits(t *testing.T) {
+	cells := int64(1) << uint64(redisBitDepth/2)
+	scenarios := []struct {
+		desc   string
+		lat    float64
+		lng    float64
+		latIdx int64
+		lngIdx int64
+	}{
+		{desc: "south west", lat: RedisMinLat, lng: -180, latIdx: 0, lngIdx: 0},
+		{desc: "north west", lat: RedisMaxLat, lng: -180, latIdx: cells - 1, lngIdx: 0},
+		{desc: "south east", lat: RedisMinLat, lng: 180, latIdx: 0, lngIdx: cells - 1},
+		{desc: "north east", lat: RedisMaxLat, lng: 180, latIdx: cells - 1, lngIdx: cells - 1},
+	}
+
+	for _, scenario := range scenarios {
+		score := EncodeRedisScore(scenario.lat, scenario.lng)
+		if latIdx, lngIdx := deinterleave(int64(score), redisBitDepth); latIdx != scenario.latIdx || lngIdx != scenario.lngIdx {
+			t.Errorf("%s: Expected %+v,%+v but was %+v,%+v", scenario.desc, scenario.latIdx, scenario.lngIdx, latIdx, lngIdx)
+		}
+
+		// decoding returns the center of the cell at the limit
+		lat, lng := DecodeRedisScore(score)
+		if math.Abs(lat-scenario.lat) > 1e-5 || math.Abs(lng-scenario.lng) > 1e-5 {
+			t.Errorf("%s: Expected %+v,%+v but was %+v,%+v", scenario.desc, scenario.lat, scenario.lng, lat, lng)
+		}
+	}
+}
+
+func TestEncodeRedisScoreRange(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected panic() for a latitude outside of the Redis range")
+		}
+	}()
+	EncodeRedisScore(86, 0)
+}
+
+func TestConvertLatRange(t *testing.T) {
+	points := []Point{{Lat: 37.8324, Lng: 112.5584}, {Lat: -33.8688, Lng: 151.2093}, {Lat: 0, Lng: 0}, {Lat: 85, Lng: -179.9}}
+	for _, point := range points {
+		// from Redis to the standard range gives the standard cell of the position Redis returns
+		score := int64(EncodeRedisScore(point.Lat, point.Lng))
+		lat, lng := DecodeRedisScore(float64(score))
+		expected := EncodeInt(lat, lng, MaxBitDepth)
+		if result := ConvertLatRange(score, MaxBitDepth, RedisMinLat, RedisMaxLat, -90, 90); expected != result {
+			t.Errorf("%+v: Expected %+v but was %+v", point, expected, result)
+		}
+
+		// converting to the (finer) Redis rows and back gives the original standard cell
+		geohash := EncodeInt(point.Lat, point.Lng, MaxBitDepth)
+		redis := ConvertLatRange(geohash, MaxBitDepth, -90, 90, RedisMinLat, RedisMaxLat)
+		if result := ConvertLatRange(redis, MaxBitDepth, RedisMinLat, RedisMaxLat, -90, 90); geohash != result {
+			t.Errorf("%+v: Expected %+v but was %+v", point, geohash, result)
+		}
+	}
+
+	// polar cells are clamped into the Redis range
+	if result := ConvertLatRange(EncodeInt(89, 10, 20), 20, -90, 90, RedisMinLat, RedisMaxLat); result != interleave(1023, 540, 20) {
+		latIdx, lngIdx := deinterleave(result, 20)
+		t.Errorf("Expected %+v but was %+v (%d, %d)", interleave(1023, 540, 20), result, latIdx, lngIdx)
+	}
+}
@@ -0,0 +1,67 @@
+package geohash
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// maxRenderColumns is the widest grid, in characters, that RenderASCII will draw.
+	maxRenderColumns = 80
+
+	// maxRenderRows is the tallest grid, in lines, that RenderASCII will draw.
+	maxRenderRows = 40
+)
+
+// RenderASCII will draw a covering as a grid of characters for debugging, '#' for a cell in the covering and '.' for
+// one that is not, spanning the bounding box of the covering (see UnionBbox) with north at the top.
+//
+// The grid is labelled with the coordinates of its north west corner on the first line and its south east corner on
+// the last one. Coverings more than 80 cells wide or 40 cells tall are scaled down so that each character is a block
+// of cells, drawn as '#' when any of them are in the covering. An empty covering returns an empty string.
+func RenderASCII(hashes []int64, bitDepth int64) string {
+	// input validation
+	validateBitDepth(bitDepth)
+
+	b, ok := UnionBbox(hashes, bitDepth)
+	if !ok {
+		return ""
+	}
+
+	// the south west cell of the bbox and its size in cells, which may wrap around the antimeridian
+	cells := int64(1) << uint64(bitDepth/2)
+	_, _, latErr, lngErr := DecodeInt(0, bitDepth)
+	minLatIdx := int64(math.Round((b.MinLat + 90) / (latErr * 2)))
+	westIdx := int64(math.Round((b.MinLng + 180) / (lngErr * 2)))
+	rows := int64(math.Round((b.MaxLat - b.MinLat) / (latErr * 2)))
+	width := b.MaxLng - b.MinLng
+	if b.CrossesAntimeridian() {
+		width += 360
+	}
+	columns := int64(math.Round(width / (lngErr * 2)))
+
+	// each character is a block of cells when the covering is too large to draw one per cell
+	blockRows := (rows + maxRenderRows - 1) / maxRenderRows
+	blockColumns := (columns + maxRenderColumns - 1) / maxRenderColumns
+	grid := make([][]byte, (rows+blockRows-1)/blockRows)
+	for row := range grid {
+		grid[row] = []byte(strings.Repeat(".", int((columns+blockColumns-1)/blockColumns)))
+	}
+	for _, geohash := range hashes {
+		latIdx, lngIdx := deinterleave(geohash, bitDepth)
+		row := (latIdx - minLatIdx) / blockRows
+		column := ((lngIdx-westIdx)%cells + cells) % cells / blockColumns
+		grid[len(grid)-1-int(row)][column] = '#'
+	}
+
+	decimals := DisplayDecimals(bitDepth)
+	var output strings.Builder
+	fmt.Fprintf(&output, "%.*f,%.*f\n", decimals, b.MaxLat, decimals, b.MinLng)
+	for _, line := range grid {
+		output.Write(line)
+		output.WriteByte('\n')
+	}
+	fmt.Fprintf(&output, "%*s\n", len(grid[0]), fmt.Sprintf("%.*f,%.*f", decimals, b.MinLat, decimals, b.MaxLng))
+	return output.String()
+}
@@ -0,0 +1,50 @@
+package geohash
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderASCII(t *testing.T) {
+	// an L shape at bit depth 4, where cells are 45 by 90 degrees
+	hashes := []int64{EncodeInt(-80, -170, 4), EncodeInt(-80, -80, 4), EncodeInt(-30, -170, 4)}
+
+	expected := strings.Join([]string{
+		"0,-180",
+		"#.",
+		"##",
+		"-90,0",
+		"",
+	}, "\n")
+	if result := RenderASCII(hashes, 4); expected != result {
+		t.Errorf("Expected %q but was %q", expected, result)
+	}
+
+	if result := RenderASCII(nil, 4); result != "" {
+		t.Errorf("Expected an empty string but was %q", result)
+	}
+}
+
+func TestRenderASCIIAntimeridian(t *testing.T) {
+	hashes := []int64{EncodeInt(10, 179.99, 10), EncodeInt(10, -179.99, 10)}
+
+	lines := strings.Split(RenderASCII(hashes, 10), "\n")
+	if len(lines) != 4 || lines[1] != "##" {
+		t.Errorf("Expected a single row of 2 cells but was %q", lines)
+	}
+}
+
+func TestRenderASCIIScaled(t *testing.T) {
+	hashes := BboxesInt(10, 10, 30, 60, 24)
+
+	lines := strings.Split(strings.TrimSuffix(RenderASCII(hashes, 24), "\n"), "\n")
+	grid := lines[1 : len(lines)-1]
+	if len(grid) > maxRenderRows || len(grid[0]) > maxRenderColumns {
+		t.Errorf("Expected at most %dx%d but was %dx%d", maxRenderColumns, maxRenderRows, len(grid[0]), len(grid))
+	}
+	for _, line := range grid {
+		if strings.Contains(line, ".") {
+			t.Errorf("Expected a solid grid but was %q", line)
+		}
+	}
+}
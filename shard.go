@@ -0,0 +1,53 @@
+package geohash
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// ShardMode defines how ShardKey trades spatial locality against spreading load across shards.
+type ShardMode int
+
+const (
+	// ShardScatter spreads cells pseudo-randomly across the shards, so that adjacent cells are usually on different
+	// shards and a hot region is shared between many of them. Queries over a region touch most shards.
+	ShardScatter ShardMode = iota
+
+	// ShardCluster splits the geohash curve into shards contiguous ranges of equal size, so that adjacent cells are
+	// usually on the same shard and a region query touches few shards. A hot region overloads its shard.
+	ShardCluster
+)
+
+// ShardKey will return the index, from 0 to shards-1, of the shard that a geohash integer belongs to.
+//
+// The mapping depends only on the geohash, bitDepth, shards and mode, so it is stable across runs and platforms.
+// With ShardCluster a cell is on the same shard as all of its children at any finer bitDepth, as the shards are
+// ranges of the geohash curve; with ShardScatter the shard of each bitDepth is independent.
+func ShardKey(geohash int64, bitDepth int64, shards int, mode ShardMode) int {
+	// input validation
+	validateBitDepth(bitDepth)
+	if shards <= 0 {
+		panic(fmt.Sprintf("shards must be greater than 0, was %d", shards))
+	}
+
+	switch mode {
+	case ShardScatter:
+		return int(mix64(uint64(geohash)^uint64(bitDepth)<<(64-8)) % uint64(shards))
+
+	case ShardCluster:
+		// geohash*shards / 2^bitDepth without overflowing
+		hi, lo := bits.Mul64(uint64(geohash), uint64(shards))
+		return int(hi<<uint64(64-bitDepth) | lo>>uint64(bitDepth))
+
+	default:
+		panic(fmt.Sprintf("unknown shard mode %d", mode))
+	}
+}
+
+// mix64 is the SplitMix64 finalizer, which scrambles the bits of x so that similar inputs give unrelated outputs.
+func mix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
@@ -0,0 +1,62 @@
+package geohash
+
+import (
+	"testing"
+)
+
+func TestShardKeyCluster(t *testing.T) {
+	scenarios := []struct {
+		desc     string
+		geohash  int64
+		bitDepth int64
+		shards   int
+		expected int
+	}{
+		{desc: "first", geohash: 0, bitDepth: 20, shards: 4, expected: 0},
+		{desc: "last", geohash: 1<<20 - 1, bitDepth: 20, shards: 4, expected: 3},
+		{desc: "quarter", geohash: 1 << 18, bitDepth: 20, shards: 4, expected: 1},
+		{desc: "uneven", geohash: 1<<20 - 1, bitDepth: 20, shards: 7, expected: 6},
+		{desc: "max bitDepth", geohash: 1<<MaxBitDepth64 - 1, bitDepth: MaxBitDepth64, shards: 1000, expected: 999},
+		{desc: "single shard", geohash: 12345, bitDepth: 20, shards: 1, expected: 0},
+	}
+
+	for _, scenario := range scenarios {
+		result := ShardKey(scenario.geohash, scenario.bitDepth, scenario.shards, ShardCluster)
+		if scenario.expected != result {
+			t.Errorf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected, result)
+		}
+	}
+
+	// children stay on the parent's shard
+	geohash := EncodeInt(37.8324, 112.5584, 20)
+	for _, child := range ChildrenInt(geohash, 20) {
+		if expected, result := ShardKey(geohash, 20, 13, ShardCluster), ShardKey(child, 22, 13, ShardCluster); expected != result {
+			t.Errorf("Expected %+v but was %+v", expected, result)
+		}
+	}
+}
+
+func TestShardKeyScatter(t *testing.T) {
+	// stable across runs and platforms
+	if result := ShardKey(4064984913515641, MaxBitDepth, 1000, ShardScatter); result != 923 {
+		t.Errorf("Expected %+v but was %+v", 923, result)
+	}
+	if result := ShardKey(0, 2, 1000, ShardScatter); result != 655 {
+		t.Errorf("Expected %+v but was %+v", 655, result)
+	}
+
+	// a neighborhood spreads across most shards while clustered it stays on one
+	hashes := BboxesInt(37.8, 112.5, 37.9, 112.6, 30)
+	scattered := map[int]bool{}
+	clustered := map[int]bool{}
+	for _, geohash := range hashes {
+		scattered[ShardKey(geohash, 30, 16, ShardScatter)] = true
+		clustered[ShardKey(geohash, 30, 16, ShardCluster)] = true
+	}
+	if len(scattered) != 16 {
+		t.Errorf("Expected %+v shards but was %+v for %d cells", 16, len(scattered), len(hashes))
+	}
+	if len(clustered) != 1 {
+		t.Errorf("Expected %+v shards but was %+v", 1, len(clustered))
+	}
+}
@@ -0,0 +1,80 @@
+package geohash
+
+import (
+	"math"
+)
+
+const (
+	// EarthRadiusMeters is the mean radius of the Earth (IUGG) used for all spherical calculations.
+	EarthRadiusMeters float64 = 6371008.8
+)
+
+// vector is a point on the unit sphere in earth-centered cartesian form.
+type vector struct {
+	x, y, z float64
+}
+
+// toVector converts a latitude and longitude (in degrees) into a unit vector.
+func toVector(lat float64, lng float64) vector {
+	latRad := lat * math.Pi / 180
+	lngRad := lng * math.Pi / 180
+	return vector{
+		x: math.Cos(latRad) * math.Cos(lngRad),
+		y: math.Cos(latRad) * math.Sin(lngRad),
+		z: math.Sin(latRad),
+	}
+}
+
+// toLatLng converts a (not necessarily unit length) vector back into latitude and longitude in degrees.
+func (v vector) toLatLng() (lat float64, lng float64) {
+	lat = math.Atan2(v.z, math.Hypot(v.x, v.y)) * 180 / math.Pi
+	lng = math.Atan2(v.y, v.x) * 180 / math.Pi
+	return
+}
+
+// angleBetween returns the angle in radians between two unit vectors.
+func angleBetween(a vector, b vector) float64 {
+	c := cross(a, b)
+	return math.Atan2(math.Sqrt(dot(c, c)), dot(a, b))
+}
+
+// cross returns the cross product of two vectors.
+func cross(a vector, b vector) vector {
+	return vector{
+		x: a.y*b.z - a.z*b.y,
+		y: a.z*b.x - a.x*b.z,
+		z: a.x*b.y - a.y*b.x,
+	}
+}
+
+// dot returns the dot product of two vectors.
+func dot(a vector, b vector) float64 {
+	return a.x*b.x + a.y*b.y + a.z*b.z
+}
+
+// slerp returns the point a fraction t of the way along the great circle from a to b, which are separated by angle.
+func slerp(a vector, b vector, angle float64, t float64) vector {
+	if angle == 0 {
+		return a
+	}
+	sinAngle := math.Sin(angle)
+	wa := math.Sin((1-t)*angle) / sinAngle
+	wb := math.Sin(t*angle) / sinAngle
+	return vector{
+		x: wa*a.x + wb*b.x,
+		y: wa*a.y + wb*b.y,
+		z: wa*a.z + wb*b.z,
+	}
+}
+
+// normalizeLng wraps a longitude into the range [-180, 180].
+func normalizeLng(lng float64) float64 {
+	if lng >= -180 && lng <= 180 {
+		return lng
+	}
+	lng = math.Mod(lng+180, 360)
+	if lng < 0 {
+		lng += 360
+	}
+	return lng - 180
+}
@@ -0,0 +1,97 @@
+package geohash
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// NullHash is a Hash that may be NULL, for storing in a database as a base32 geohash string with database/sql.
+//
+// Only the bit depths that ParseHash returns can be stored as a string and parsed back unchanged (see
+// Hash.MarshalText), so any string scanned can be stored again but other bit depths return an error from Value; use
+// NullInt64Hash for them.
+type NullHash struct {
+	Hash  Hash
+	Valid bool // Valid is true if Hash is not NULL
+}
+
+// NullInt64Hash is a Hash that may be NULL, for storing in a database as a single int64 with database/sql.
+//
+// The stored value is the geohash integer with a marker bit set just above its bitDepth bits (1<<BitDepth | Value) so
+// that the bitDepth can be recovered. Unlike NullHash this round trips for every bitDepth.
+type NullInt64Hash struct {
+	Hash  Hash
+	Valid bool // Valid is true if Hash is not NULL
+}
+
+// Value implements driver.Valuer, storing the Hash as a base32 geohash string or NULL when it is not Valid.
+func (n NullHash) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	text, err := n.Hash.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(text), nil
+}
+
+// Scan implements sql.Scanner, reading a geohash stored by either NullHash or NullInt64Hash.
+func (n *NullHash) Scan(src interface{}) error {
+	hash, valid, err := scanHash(src)
+	if err != nil {
+		return err
+	}
+	n.Hash, n.Valid = hash, valid
+	return nil
+}
+
+// Value implements driver.Valuer, storing the Hash as 1<<BitDepth | Value or NULL when it is not Valid.
+func (n NullInt64Hash) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	if err := checkHash(n.Hash); err != nil {
+		return nil, err
+	}
+	return int64(1)<<uint64(n.Hash.BitDepth) | n.Hash.Value, nil
+}
+
+// Scan implements sql.Scanner, reading a geohash stored by either NullHash or NullInt64Hash.
+func (n *NullInt64Hash) Scan(src interface{}) error {
+	hash, valid, err := scanHash(src)
+	if err != nil {
+		return err
+	}
+	n.Hash, n.Valid = hash, valid
+	return nil
+}
+
+// scanHash converts a database value into a Hash, returning false for NULL.
+func scanHash(src interface{}) (Hash, bool, error) {
+	switch value := src.(type) {
+	case nil:
+		return Hash{}, false, nil
+
+	case string:
+		var hash Hash
+		err := hash.UnmarshalText([]byte(value))
+		return hash, err == nil, err
+
+	case []byte:
+		var hash Hash
+		err := hash.UnmarshalText(value)
+		return hash, err == nil, err
+
+	case int64:
+		for bitDepth := int64(2); bitDepth <= MaxBitDepth64; bitDepth += 2 {
+			if value>>uint64(bitDepth) == 1 {
+				return Hash{Value: value &^ (int64(1) << uint64(bitDepth)), BitDepth: bitDepth}, true, nil
+			}
+		}
+		return Hash{}, false, fmt.Errorf("invalid stored geohash %d", value)
+
+	default:
+		return Hash{}, false, fmt.Errorf("cannot scan %T into a geohash", src)
+	}
+}
@@ -0,0 +1,141 @@
+package geohash
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+var (
+	_ driver.Valuer = NullHash{}
+	_ sql.Scanner   = &NullHash{}
+	_ driver.Valuer = NullInt64Hash{}
+	_ sql.Scanner   = &NullInt64Hash{}
+)
+
+func TestNullHashValueScan(t *testing.T) {
+	scenarios := []struct {
+		desc     string
+		hash     NullHash
+		expected driver.Value
+	}{
+		{
+			desc:     "30 bits",
+			hash:     NullHash{Hash: Hash{Value: EncodeInt(57.64911, 10.40744, 30), BitDepth: 30}, Valid: true},
+			expected: "u4pruy",
+		},
+		{
+			desc:     "max bit depth",
+			hash:     NullHash{Hash: Hash{Value: 4064984913515641, BitDepth: MaxBitDepth}, Valid: true},
+			expected: FormatHash(4064984913515641, MaxBitDepth, ""),
+		},
+		{
+			desc:     "null",
+			hash:     NullHash{},
+			expected: nil,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		value, err := scenario.hash.Value()
+		if err != nil || value != scenario.expected {
+			t.Errorf("%s: Expected %+v but was %+v (%v)", scenario.desc, scenario.expected, value, err)
+		}
+
+		var result NullHash
+		if err := result.Scan(value); err != nil || result != scenario.hash {
+			t.Errorf("%s: Expected %+v but was %+v (%v)", scenario.desc, scenario.hash, result, err)
+		}
+	}
+}
+
+func TestNullHashValueScanBitDepths(t *testing.T) {
+	for bitDepth := int64(2); bitDepth <= MaxBitDepth64; bitDepth += 2 {
+		hash := NullHash{Hash: Hash{Value: EncodeInt(-37.8136, 144.9631, bitDepth), BitDepth: bitDepth}, Valid: true}
+
+		value, err := hash.Value()
+		if bitDepth%10 != 0 && bitDepth%10 != 4 && bitDepth != MaxBitDepth && bitDepth != 60 && bitDepth != MaxBitDepth64 || bitDepth == 54 {
+			if err == nil {
+				t.Errorf("%d: Expected an error but was %+v", bitDepth, value)
+			}
+			continue
+		}
+
+		var result NullHash
+		if err := result.Scan(value); err != nil || result != hash {
+			t.Errorf("%d: Expected %+v but was %+v (%v)", bitDepth, hash, result, err)
+		}
+	}
+}
+
+func TestNullHashScanValueOddLength(t *testing.T) {
+	var hash NullHash
+	if err := hash.Scan("ww8p1r4t8"); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	// a row read and written back is unchanged
+	if value, err := hash.Value(); err != nil || value != "ww8p1r4t8" {
+		t.Errorf("Expected %+v but was %+v (%v)", "ww8p1r4t8", value, err)
+	}
+
+	// a string that could not be written back unchanged is rejected
+	if err := hash.Scan("ww8p1r4t9"); err == nil {
+		t.Errorf("Expected an error but was %+v", hash)
+	}
+}
+
+func TestNullHashValueInvalid(t *testing.T) {
+	scenarios := []NullHash{
+		{Hash: Hash{Value: 1, BitDepth: 26}, Valid: true},
+		{Hash: Hash{Value: 1, BitDepth: 3}, Valid: true},
+		{Hash: Hash{Value: 16, BitDepth: 4}, Valid: true},
+	}
+
+	for _, scenario := range scenarios {
+		if _, err := scenario.Value(); err == nil {
+			t.Errorf("Expected an error for %+v", scenario)
+		}
+	}
+}
+
+func TestNullInt64HashValueScan(t *testing.T) {
+	for bitDepth := int64(2); bitDepth <= MaxBitDepth64; bitDepth += 2 {
+		hash := NullInt64Hash{Hash: Hash{Value: EncodeInt(-37.8136, 144.9631, bitDepth), BitDepth: bitDepth}, Valid: true}
+
+		value, err := hash.Value()
+		if err != nil {
+			t.Fatalf("Expected no error but was %v", err)
+		}
+
+		var result NullInt64Hash
+		if err := result.Scan(value); err != nil || result != hash {
+			t.Errorf("Expected %+v but was %+v (%v)", hash, result, err)
+		}
+	}
+
+	if value, err := (NullInt64Hash{}).Value(); err != nil || value != nil {
+		t.Errorf("Expected %+v but was %+v (%v)", nil, value, err)
+	}
+}
+
+func TestNullHashScan(t *testing.T) {
+	result := NullHash{Hash: Hash{Value: 5, BitDepth: 4}, Valid: true}
+	if err := result.Scan(nil); err != nil || result.Valid || result.Hash != (Hash{}) {
+		t.Errorf("Expected NULL but was %+v (%v)", result, err)
+	}
+
+	// either storage format can be read
+	if err := result.Scan([]byte("u4pruy")); err != nil || !result.Valid || result.Hash.BitDepth != 30 {
+		t.Errorf("Expected a valid hash but was %+v (%v)", result, err)
+	}
+	if err := result.Scan(int64(1<<4 | 5)); err != nil || result.Hash != (Hash{Value: 5, BitDepth: 4}) {
+		t.Errorf("Expected a valid hash but was %+v (%v)", result, err)
+	}
+
+	for _, src := range []interface{}{"u4a", int64(0), int64(-1), 1.5} {
+		if err := result.Scan(src); err == nil {
+			t.Errorf("Expected an error scanning %+v", src)
+		}
+	}
+}
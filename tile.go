@@ -0,0 +1,168 @@
+package geohash
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	// maxTileLat is the northern most latitude of Web Mercator (slippy map) tiles, which do not reach the poles.
+	maxTileLat = 85.0511287798066
+
+	// tilePixels is the width and height in pixels of a slippy map tile.
+	tilePixels = 256
+
+	// maxViewportCells is the most cells CoverViewportInt will return.
+	maxViewportCells = 1024
+)
+
+// HashToTile will return the x, y and zoom (z) of the slippy map (XYZ) tile, as used by OSM and Leaflet, that contains
+// the center of a geohash integer's cell.
+//
+// Tiles start from x 0 at -180 longitude and y 0 at the northern edge of Web Mercator, about +85.05 latitude, so a
+// center nearer the poles than that is clamped into the northern or southern most row of tiles. Panics when zoom is
+// not between 0 and 30.
+func HashToTile(geohash int64, bitDepth int64, zoom int) (x int, y int, z int) {
+	// input validation
+	validateBitDepth(bitDepth)
+	validateZoom(zoom)
+
+	lat, lng, _, _ := DecodeInt(geohash, bitDepth)
+	return tileX(lng, zoom, false), tileY(lat, zoom, false), zoom
+}
+
+// TilesForCovering will return the slippy map (XYZ) tiles at zoom that any of the supplied geohash integers' cells
+// overlap, as x, y, z triples sorted by row from the north west and without duplicates.
+//
+// As with HashToTile, cells nearer the poles than Web Mercator reaches are clamped into the northern or southern
+// most row of tiles. A cell that only touches the edge of a tile does not overlap it.
+func TilesForCovering(hashes []int64, bitDepth int64, zoom int) [][3]int {
+	// input validation
+	validateBitDepth(bitDepth)
+	validateZoom(zoom)
+
+	seen := map[[3]int]bool{}
+	var output [][3]int
+	for _, geohash := range hashes {
+		minLat, minLng, maxLat, maxLng := DecodeBboxInt(geohash, bitDepth)
+
+		// the south and east edges are exclusive so that a cell ending on a tile boundary does not include the next tile
+		for y := tileY(maxLat, zoom, false); y <= tileY(minLat, zoom, true); y++ {
+			for x := tileX(minLng, zoom, false); x <= tileX(maxLng, zoom, true); x++ {
+				if tile := [3]int{x, y, zoom}; !seen[tile] {
+					seen[tile] = true
+					output = append(output, tile)
+				}
+			}
+		}
+	}
+
+	sort.Slice(output, func(i, j int) bool {
+		if output[i][1] != output[j][1] {
+			return output[i][1] < output[j][1]
+		}
+		return output[i][0] < output[j][0]
+	})
+	return output
+}
+
+// CoverViewportInt will return a covering of the area shown by a Web Mercator (slippy map) viewport of widthPx by
+// heightPx pixels centered on centerLat, centerLng at zoom, such as for querying what a map is showing after it is
+// panned or zoomed.
+//
+// The viewport is converted into a lat/lng bbox using 256 pixel tiles, clamped to the northern and southern edges of
+// the map (about +/-85.05 latitude) and spanning all longitudes when it is wider than the map. The cells are all of
+// the deepest bitDepth, up to MaxBitDepth, for which covering the bbox takes no more than 1024 cells, so the
+// covering can always be rendered. A viewport that crosses the antimeridian is covered either side of it. The
+// results are sorted ascending. Panics when zoom is not between 0 and 30 or the viewport has no pixels.
+func CoverViewportInt(centerLat float64, centerLng float64, zoom int, widthPx int, heightPx int) []Hash {
+	// input validation
+	validateZoom(zoom)
+	if widthPx <= 0 || heightPx <= 0 {
+		panic(fmt.Sprintf("viewport must have pixels, was %dx%d", widthPx, heightPx))
+	}
+
+	mapPixels := float64(tilePixels) * math.Exp2(float64(zoom))
+	centerY := mercatorY(centerLat) * mapPixels
+	minLat := mercatorLat(math.Min(centerY+float64(heightPx)/2, mapPixels) / mapPixels)
+	maxLat := mercatorLat(math.Max(centerY-float64(heightPx)/2, 0) / mapPixels)
+
+	minLng, maxLng := -180.0, 180.0
+	if float64(widthPx) < mapPixels {
+		halfWidth := float64(widthPx) / 2 / mapPixels * 360
+		minLng = normalizeLng(centerLng - halfWidth)
+		maxLng = normalizeLng(centerLng + halfWidth)
+	}
+
+	bitDepth := int64(2)
+	for bitDepth < MaxBitDepth {
+		if cells, _ := EstimateCoveringSize(minLat, minLng, maxLat, maxLng, bitDepth+2); cells > maxViewportCells {
+			break
+		}
+		bitDepth += 2
+	}
+
+	var hashes []int64
+	if BboxCrossesAntimeridian(minLng, maxLng) {
+		hashes = append(BboxesInt(minLat, minLng, maxLat, 180, bitDepth), BboxesInt(minLat, -180, maxLat, maxLng, bitDepth)...)
+	} else {
+		hashes = BboxesInt(minLat, minLng, maxLat, maxLng, bitDepth)
+	}
+
+	hashes = NormalizeHashes(hashes)
+	output := make([]Hash, len(hashes))
+	for index, geohash := range hashes {
+		output[index] = Hash{Value: geohash, BitDepth: bitDepth}
+	}
+	return output
+}
+
+// tileX returns the column of tiles at zoom containing lng, or when exclusive the column west of lng when it is on a
+// tile boundary.
+func tileX(lng float64, zoom int, exclusive bool) int {
+	return tileIndex((lng+180)/360, zoom, exclusive)
+}
+
+// tileY returns the row of tiles at zoom containing lat, or when exclusive the row north of lat when it is on a tile
+// boundary.
+func tileY(lat float64, zoom int, exclusive bool) int {
+	return tileIndex(mercatorY(lat), zoom, exclusive)
+}
+
+// mercatorY returns the fraction (0 to 1) of the way down the Web Mercator map from its northern edge that lat is,
+// clamping latitudes beyond the edges.
+func mercatorY(lat float64) float64 {
+	latRad := math.Max(math.Min(lat, maxTileLat), -maxTileLat) * math.Pi / 180
+	return (1 - math.Log(math.Tan(latRad)+1/math.Cos(latRad))/math.Pi) / 2
+}
+
+// mercatorLat returns the latitude the fraction (0 to 1) of the way down the Web Mercator map from its northern edge.
+func mercatorLat(y float64) float64 {
+	return math.Atan(math.Sinh(math.Pi*(1-2*y))) * 180 / math.Pi
+}
+
+// tileIndex returns the index of the tile at zoom that the fraction (0 to 1) of the way across the map is in, clamped
+// to the tiles that exist.
+func tileIndex(fraction float64, zoom int, exclusive bool) int {
+	tiles := 1 << uint(zoom)
+	position := fraction * float64(tiles)
+
+	index := int(math.Floor(position))
+	if exclusive && position == math.Floor(position) {
+		index--
+	}
+	if index < 0 {
+		return 0
+	} else if index >= tiles {
+		return tiles - 1
+	}
+	return index
+}
+
+// validateZoom will ensure the supplied slippy map zoom level is valid or cause panic() otherwise.
+func validateZoom(zoom int) {
+	if zoom < 0 || zoom > 30 {
+		panic(fmt.Sprintf("zoom must be between 0 and 30, was %d", zoom))
+	}
+}
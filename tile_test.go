@@ -0,0 +1,137 @@
+package geohash
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestHashToTile(t *testing.T) {
+	scenarios := []struct {
+		desc     string
+		lat      float64
+		lng      float64
+		zoom     int
+		expected [3]int
+	}{
+		{desc: "zoom 0", lat: 37.8324, lng: 112.5584, zoom: 0, expected: [3]int{0, 0, 0}},
+		{desc: "north east", lat: 37.8324, lng: 112.5584, zoom: 1, expected: [3]int{1, 0, 1}},
+		{desc: "south west", lat: -37.8324, lng: -112.5584, zoom: 1, expected: [3]int{0, 1, 1}},
+		// the OSM wiki example of Munich's Marienplatz
+		{desc: "munich", lat: 48.1372, lng: 11.5755, zoom: 15, expected: [3]int{17437, 11371, 15}},
+		{desc: "north pole", lat: 89.99, lng: 0.01, zoom: 4, expected: [3]int{8, 0, 4}},
+		{desc: "south pole", lat: -89.99, lng: 179.99, zoom: 4, expected: [3]int{15, 15, 4}},
+	}
+
+	for _, scenario := range scenarios {
+		x, y, z := HashToTile(EncodeInt(scenario.lat, scenario.lng, MaxBitDepth), MaxBitDepth, scenario.zoom)
+		if result := [3]int{x, y, z}; scenario.expected != result {
+			t.Errorf("%s: Expected %+v but was %+v", scenario.desc, scenario.expected, result)
+		}
+	}
+}
+
+func TestTilesForCovering(t *testing.T) {
+	// at zoom 2 the rows meet at 66.5 latitude, so the bit depth 4 cell from 45,0 to 90,90 overlaps two tiles while the
+	// one from 0,0 to 45,90 overlaps only the second
+	expected := [][3]int{{2, 0, 2}, {2, 1, 2}}
+	hashes := []int64{EncodeInt(10, 10, 4), EncodeInt(50, 10, 4), EncodeInt(10, 10, 4)}
+	if result := TilesForCovering(hashes, 4, 2); !reflect.DeepEqual(expected, result) {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+
+	// small cells all within one tile
+	hashes = BboxesInt(48.13, 11.57, 48.14, 11.58, 30)
+	result := TilesForCovering(hashes, 30, 10)
+	if expected := [][3]int{{544, 355, 10}}; !reflect.DeepEqual(expected, result) {
+		t.Errorf("Expected %+v but was %+v", expected, result)
+	}
+
+	// a polar cell is clamped into the northern row
+	result = TilesForCovering([]int64{EncodeInt(89, 10, 10)}, 10, 3)
+	for _, tile := range result {
+		if tile[1] != 0 {
+			t.Errorf("Expected only the northern row but was %+v", result)
+		}
+	}
+
+	if result := TilesForCovering(nil, 30, 10); len(result) != 0 {
+		t.Errorf("Expected no tiles but was %+v", result)
+	}
+}
+
+func TestCoverViewportInt(t *testing.T) {
+	scenarios := []struct {
+		desc      string
+		lat       float64
+		lng       float64
+		zoom      int
+		width     int
+		height    int
+		wholeLngs bool
+	}{
+		{desc: "city", lat: 51.5007, lng: -0.1246, zoom: 12, width: 800, height: 600},
+		{desc: "tall", lat: -37.8136, lng: 144.9631, zoom: 15, width: 300, height: 1200},
+		{desc: "antimeridian", lat: -17.7134, lng: 179.9, zoom: 8, width: 800, height: 600},
+		{desc: "whole map", lat: 0, lng: 0, zoom: 0, width: 1024, height: 768, wholeLngs: true},
+	}
+
+	for _, scenario := range scenarios {
+		result := CoverViewportInt(scenario.lat, scenario.lng, scenario.zoom, scenario.width, scenario.height)
+		if len(result) == 0 || len(result) > maxViewportCells {
+			t.Fatalf("%s: Expected up to %+v cells but was %+v", scenario.desc, maxViewportCells, len(result))
+		}
+
+		bitDepth := result[0].BitDepth
+		hashes := make([]int64, len(result))
+		for index, hash := range result {
+			if hash.BitDepth != bitDepth {
+				t.Errorf("%s: Expected %+v but was %+v", scenario.desc, bitDepth, hash.BitDepth)
+			}
+			hashes[index] = hash.Value
+		}
+		assertSortedUnique(t, hashes)
+		included := toSet(hashes)
+
+		// every edge of the viewport is covered
+		mapPixels := 256 * math.Exp2(float64(scenario.zoom))
+		west, east := -180.0, 180.0
+		if !scenario.wholeLngs {
+			west = normalizeLng(scenario.lng - float64(scenario.width)/2/mapPixels*360 + 1e-9)
+			east = normalizeLng(scenario.lng + float64(scenario.width)/2/mapPixels*360 - 1e-9)
+		}
+		centerY := mercatorY(scenario.lat) * mapPixels
+		north := mercatorLat(math.Max(centerY-float64(scenario.height)/2, 0)/mapPixels) - 1e-9
+		south := mercatorLat(math.Min(centerY+float64(scenario.height)/2, mapPixels)/mapPixels) + 1e-9
+		for _, point := range []Point{{Lat: north, Lng: west}, {Lat: north, Lng: east}, {Lat: south, Lng: west}, {Lat: south, Lng: east}, {Lat: scenario.lat, Lng: scenario.lng}} {
+			if !included[EncodeInt(point.Lat, point.Lng, bitDepth)] {
+				t.Errorf("%s: Expected %+v to be covered", scenario.desc, point)
+			}
+		}
+
+		// the next bit depth would have too many cells
+		if bitDepth < MaxBitDepth {
+			if cells, _ := EstimateCoveringSize(south, west, north, east, bitDepth+2); cells <= maxViewportCells {
+				t.Errorf("%s: Expected more than %+v cells at %+v but was %+v", scenario.desc, maxViewportCells, bitDepth+2, cells)
+			}
+		}
+	}
+}
+
+func TestCoverViewportIntInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic for a viewport without pixels")
+		}
+	}()
+	CoverViewportInt(51.5007, -0.1246, 12, 0, 600)
+}
+
+func TestHashToTileInvalidZoom(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic")
+		}
+	}()
+	HashToTile(0, 2, 31)
+}